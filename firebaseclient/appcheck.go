@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DebugToken is an App Check debug token. The API never returns the token
+// value once created.
+type DebugToken struct {
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+	UpdateTime  time.Time `json:"updateTime"`
+}
+
+// DebugTokenList is a page of debug tokens.
+type DebugTokenList struct {
+	DebugTokens   []DebugToken `json:"debugTokens"`
+	NextPageToken string       `json:"nextPageToken,omitempty"`
+}
+
+// AppCheckAppURL returns the App Check API url of an app of a project.
+func (c *Client) AppCheckAppURL(project string, app string) string {
+	return fmt.Sprintf("%s/v1/projects/%s/apps/%s", c.appCheck, project, app)
+}
+
+// ListDebugTokens returns every debug token of an app.
+func (c *Client) ListDebugTokens(ctx context.Context, project string, app string) ([]DebugToken, error) {
+	var tokens []DebugToken
+	pageToken := ""
+	for {
+		query := url.Values{"pageSize": {"50"}}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		u := c.AppCheckAppURL(project, app) + "/debugTokens?" + query.Encode()
+
+		httpReq, err := c.NewRequest(ctx, "GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		httpResp, err := c.Do(httpReq)
+		if err != nil {
+			return nil, fmt.Errorf("unable to make http request to list debug tokens: %w", err)
+		}
+
+		bodyBytes, err := io.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read app check response: %w", err)
+		}
+
+		tflog.Trace(ctx, fmt.Sprintf("app check api response %s %s", u, string(bodyBytes)))
+
+		if httpResp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("debug tokens on url: %s: %w", u, ErrNotFound)
+		}
+		if httpResp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unable to list debug tokens on url: %s, status: %d, resp: %s", u, httpResp.StatusCode, string(bodyBytes))
+		}
+
+		var page DebugTokenList
+		if err = json.Unmarshal(bodyBytes, &page); err != nil {
+			return nil, fmt.Errorf("unable to decode debug tokens on url: %s \n%s, resp: %s", u, err, string(bodyBytes))
+		}
+		tokens = append(tokens, page.DebugTokens...)
+
+		if page.NextPageToken == "" {
+			return tokens, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
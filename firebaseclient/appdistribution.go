@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultAppDistributionEndpoint is the App Distribution API endpoint used
+// when none is set.
+const DefaultAppDistributionEndpoint = "https://firebaseappdistribution.googleapis.com"
+
+// AabIntegrated is the integration state of an Android app linked to a
+// Google Play app, ready to distribute app bundles.
+const AabIntegrated = "INTEGRATED"
+
+// AabInfo describes how an Android app is linked to Google Play for app
+// bundle distribution.
+type AabInfo struct {
+	Name             string          `json:"name"`
+	IntegrationState string          `json:"integrationState"`
+	TestCertificate  *AabCertificate `json:"testCertificate,omitempty"`
+}
+
+// AabCertificate is the certificate Google Play signs the APKs generated
+// from app bundles with, only known once the app is integrated.
+type AabCertificate struct {
+	HashSha1   string `json:"hashSha1"`
+	HashSha256 string `json:"hashSha256"`
+	HashMd5    string `json:"hashMd5"`
+}
+
+// AabInfoURL returns the App Distribution API url of the app bundle
+// information of an app of a project.
+func (c *Client) AabInfoURL(project string, app string) string {
+	return fmt.Sprintf("%s/v1/projects/%s/apps/%s/aabInfo", c.appDistribution, project, app)
+}
+
+// GetAabInfo returns the app bundle information of an Android app.
+func (c *Client) GetAabInfo(ctx context.Context, project string, app string) (*AabInfo, error) {
+	u := c.AabInfoURL(project, app)
+
+	httpReq, err := c.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make http request to get aab info: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read app distribution response: %w", err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("app distribution api response %s %s", u, string(bodyBytes)))
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("aab info on url: %s: %w", u, ErrNotFound)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to get aab info on url: %s, status: %d, resp: %s", u, httpResp.StatusCode, string(bodyBytes))
+	}
+
+	var info AabInfo
+	if err = json.Unmarshal(bodyBytes, &info); err != nil {
+		return nil, fmt.Errorf("unable to decode aab info on url: %s \n%s, resp: %s", u, err, string(bodyBytes))
+	}
+
+	return &info, nil
+}
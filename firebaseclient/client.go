@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package firebaseclient is the Firebase API client used by the firebaseextra
+// Terraform provider. It is exported so platform tooling and tests can reuse
+// the exact Remote Config client the provider publishes with.
+package firebaseclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+)
+
+// DefaultEndpoint is the Remote Config API endpoint used when none is set.
+const DefaultEndpoint = "https://firebaseremoteconfig.googleapis.com"
+
+// DefaultManagementEndpoint is the Firebase Management API endpoint used
+// when none is set.
+const DefaultManagementEndpoint = "https://firebase.googleapis.com"
+
+// DefaultAppCheckEndpoint is the App Check API endpoint used when none is
+// set.
+const DefaultAppCheckEndpoint = "https://firebaseappcheck.googleapis.com"
+
+// ErrReadOnly is returned for every write attempted by a read only client.
+var ErrReadOnly = errors.New("the client is read only")
+
+// ErrNotFound is returned when the requested API resource doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// Client talks to the Firebase APIs on behalf of a token source.
+type Client struct {
+	httpClient      *http.Client
+	tokenSource     oauth2.TokenSource
+	endpoint        string
+	management      string
+	appCheck        string
+	identityToolkit string
+	appDistribution string
+	kms             string
+	requestReason   string
+	readOnly        bool
+
+	// userProjectOverride bills requests to billingProject, or to the
+	// project of their url when empty.
+	userProjectOverride bool
+	billingProject      string
+
+	// tracer records API calls as spans, nil when tracing is off.
+	tracer trace.Tracer
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the http client requests are sent with.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTokenSource sets the source of the OAuth2 tokens requests are
+// authorized with. Requests are sent unauthenticated without one, which is
+// mostly useful against fakes in tests.
+func WithTokenSource(tokenSource oauth2.TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = tokenSource
+	}
+}
+
+// WithEndpoint overrides the Remote Config API endpoint.
+func WithEndpoint(endpoint string) Option {
+	return func(c *Client) {
+		if endpoint != "" {
+			c.endpoint = endpoint
+		}
+	}
+}
+
+// WithManagementEndpoint overrides the Firebase Management API endpoint.
+func WithManagementEndpoint(endpoint string) Option {
+	return func(c *Client) {
+		if endpoint != "" {
+			c.management = endpoint
+		}
+	}
+}
+
+// WithAppCheckEndpoint overrides the App Check API endpoint.
+func WithAppCheckEndpoint(endpoint string) Option {
+	return func(c *Client) {
+		if endpoint != "" {
+			c.appCheck = endpoint
+		}
+	}
+}
+
+// WithIdentityToolkitEndpoint overrides the Identity Toolkit API endpoint.
+func WithIdentityToolkitEndpoint(endpoint string) Option {
+	return func(c *Client) {
+		if endpoint != "" {
+			c.identityToolkit = endpoint
+		}
+	}
+}
+
+// WithAppDistributionEndpoint overrides the App Distribution API endpoint.
+func WithAppDistributionEndpoint(endpoint string) Option {
+	return func(c *Client) {
+		if endpoint != "" {
+			c.appDistribution = endpoint
+		}
+	}
+}
+
+// WithKMSEndpoint overrides the Cloud KMS API endpoint.
+func WithKMSEndpoint(endpoint string) Option {
+	return func(c *Client) {
+		if endpoint != "" {
+			c.kms = endpoint
+		}
+	}
+}
+
+// WithRequestReason sets the justification sent as the
+// X-Goog-Request-Reason header for Access Transparency.
+func WithRequestReason(reason string) Option {
+	return func(c *Client) {
+		c.requestReason = reason
+	}
+}
+
+// WithUserProjectOverride sends the X-Goog-User-Project header, billing
+// requests and charging their quota to billingProject, or to the project
+// they are about when billingProject is empty, instead of to the project of
+// the credentials.
+func WithUserProjectOverride(override bool, billingProject string) Option {
+	return func(c *Client) {
+		c.userProjectOverride = override
+		c.billingProject = billingProject
+	}
+}
+
+// WithReadOnly makes every request other than GET fail with ErrReadOnly.
+func WithReadOnly(readOnly bool) Option {
+	return func(c *Client) {
+		c.readOnly = readOnly
+	}
+}
+
+// New returns a client configured with opts.
+func New(opts ...Option) *Client {
+	c := &Client{
+		httpClient:      DefaultHTTPClient(),
+		endpoint:        DefaultEndpoint,
+		management:      DefaultManagementEndpoint,
+		appCheck:        DefaultAppCheckEndpoint,
+		identityToolkit: DefaultIdentityToolkitEndpoint,
+		appDistribution: DefaultAppDistributionEndpoint,
+		kms:             DefaultKMSEndpoint,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// DefaultHTTPClient returns the http client used when none is set. Its
+// timeout only applies to requests whose context has no deadline.
+func DefaultHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 15 * time.Second,
+		Transport: &http.Transport{
+			Dial: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).Dial,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+	}
+}
+
+// NewRequest builds an authenticated request to a Google API.
+func (c *Client) NewRequest(ctx context.Context, method string, url string, body io.Reader) (*http.Request, error) {
+	if c.readOnly && method != http.MethodGet {
+		return nil, fmt.Errorf("%s %s: %w", method, url, ErrReadOnly)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.tokenSource != nil {
+		token, err := c.tokenSource.Token()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get an access token: %w", err)
+		}
+		token.SetAuthHeader(httpReq)
+	}
+	if c.requestReason != "" {
+		httpReq.Header.Set("X-Goog-Request-Reason", c.requestReason)
+	}
+	if c.userProjectOverride {
+		project := c.billingProject
+		if project == "" {
+			project = urlProject(httpReq.URL.Path)
+		}
+		if project != "" {
+			httpReq.Header.Set("X-Goog-User-Project", project)
+		}
+	}
+
+	return httpReq, nil
+}
+
+// urlProject returns the project an API url path is about, "" when it isn't
+// about a single project.
+func urlProject(urlPath string) string {
+	_, rest, ok := strings.Cut(urlPath, "/projects/")
+	if !ok {
+		return ""
+	}
+	project, _, _ := strings.Cut(rest, "/")
+	project, _, _ = strings.Cut(project, ":")
+	if project == "-" {
+		return ""
+	}
+
+	return project
+}
+
+// ReadOnly reports whether the client refuses writes.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// Do sends a request with the client's http client. The deadline of the
+// request context, e.g. from a resource timeout, replaces the timeout of the
+// http client.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.tracer != nil {
+		return c.traced(req, c.send)
+	}
+
+	return c.send(req)
+}
+
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); ok && c.httpClient.Timeout != 0 {
+		httpClient := *c.httpClient
+		httpClient.Timeout = 0
+		return httpClient.Do(req)
+	}
+
+	return c.httpClient.Do(req)
+}
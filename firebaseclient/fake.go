@@ -0,0 +1,495 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeUpdateUser is the email the fake records as the author of every
+// version.
+const FakeUpdateUser = "mock@firebaseextra.invalid"
+
+// FakeTransport is an in-memory stand-in for the Remote Config, Firebase
+// Management and Identity Toolkit APIs, answering requests sent to any endpoint. Every project
+// exists and starts with an empty template at version 1. Publishes bump the
+// version number and the etag like the real API does, so computed values
+// look like the ones of a live project.
+//
+// When StateFile is set the fake state is loaded from and saved to it around
+// every request, so it survives across provider processes.
+type FakeTransport struct {
+	StateFile string
+
+	mu    sync.Mutex
+	state fakeState
+}
+
+type fakeState struct {
+	Projects map[string]*fakeProject `json:"projects"`
+}
+
+type fakeProject struct {
+	Project   FirebaseProject   `json:"project"`
+	Analytics *AnalyticsDetails `json:"analytics,omitempty"`
+	// Versions holds every published template, version n at index n-1.
+	Versions []json.RawMessage `json:"versions"`
+	// Users holds the imported user accounts by tenant, "" for the project
+	// itself, and local ID.
+	Users map[string]map[string]UserAccount `json:"users,omitempty"`
+}
+
+// NewFakeTransport returns a fake persisted to stateFile, kept in memory
+// only when stateFile is empty.
+func NewFakeTransport(stateFile string) *FakeTransport {
+	return &FakeTransport{StateFile: stateFile}
+}
+
+func (f *FakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	status, header, resp := f.serve(req, body)
+	if req.Method != http.MethodGet && status == http.StatusOK {
+		if err := f.save(); err != nil {
+			return nil, err
+		}
+	}
+
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(resp)),
+		Request:    req,
+	}, nil
+}
+
+func (f *FakeTransport) load() error {
+	if f.state.Projects == nil {
+		f.state.Projects = map[string]*fakeProject{}
+	}
+	if f.StateFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(f.StateFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read fake state: %w", err)
+	}
+	if err := json.Unmarshal(data, &f.state); err != nil {
+		return fmt.Errorf("unable to decode fake state %s: %w", f.StateFile, err)
+	}
+	if f.state.Projects == nil {
+		f.state.Projects = map[string]*fakeProject{}
+	}
+
+	return nil
+}
+
+func (f *FakeTransport) save() error {
+	if f.StateFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(f.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(f.StateFile, data, 0o600); err != nil {
+		return fmt.Errorf("unable to write fake state: %w", err)
+	}
+
+	return nil
+}
+
+// serve routes a request on its path, ignoring the endpoint it was sent to.
+func (f *FakeTransport) serve(req *http.Request, body []byte) (int, http.Header, []byte) {
+	p := req.URL.Path
+	switch {
+	case strings.HasPrefix(p, "/v1/projects/"):
+		id, method, _ := strings.Cut(strings.TrimPrefix(p, "/v1/projects/"), "/")
+		if rest, ok := strings.CutPrefix(method, "namespaces/"); ok {
+			// Every namespace has a template of its own.
+			namespace, serverMethod, _ := strings.Cut(rest, "/")
+			id = TemplateRef(id, namespace)
+			method = strings.Replace(serverMethod, "serverRemoteConfig", "remoteConfig", 1)
+		}
+		project := f.project(id)
+		switch {
+		case method == "remoteConfig" && req.Method == http.MethodGet:
+			return project.getTemplate(req.URL.Query().Get("versionNumber"))
+		case method == "remoteConfig" && req.Method == http.MethodPut:
+			return project.publish(body, req.Header.Get("If-Match"), req.URL.Query().Get("validateOnly") == "true")
+		case method == "remoteConfig:listVersions" && req.Method == http.MethodGet:
+			return project.listVersions(req.URL.Query().Get("endVersionNumber"))
+		case method == "remoteConfig:rollback" && req.Method == http.MethodPost:
+			return project.rollback(body)
+		case strings.HasPrefix(method, "accounts:") || strings.HasPrefix(method, "tenants/"):
+			tenant := ""
+			if rest, ok := strings.CutPrefix(method, "tenants/"); ok {
+				tenant, method, _ = strings.Cut(rest, "/")
+			}
+			if req.Method == http.MethodPost {
+				return project.accounts(tenant, strings.TrimPrefix(method, "accounts:"), body)
+			}
+		case strings.HasPrefix(method, "apps/") && strings.HasSuffix(method, "/debugTokens") && req.Method == http.MethodGet:
+			// Debug tokens are created in the console, the fake has none.
+			return fakeJSON(http.StatusOK, DebugTokenList{})
+		case strings.HasPrefix(method, "apps/") && strings.HasSuffix(method, "/aabInfo") && req.Method == http.MethodGet:
+			// Play is linked in the console, no app of the fake is.
+			return fakeJSON(http.StatusOK, AabInfo{Name: strings.TrimPrefix(p, "/v1/"), IntegrationState: "PLAY_ACCOUNT_NOT_LINKED"})
+		case strings.HasPrefix(method, "locations/") && strings.HasSuffix(method, ":decrypt") && req.Method == http.MethodPost:
+			return fakeDecrypt(body)
+		}
+	case strings.HasPrefix(p, "/v1beta1/operations/"):
+		return fakeJSON(http.StatusOK, Operation{Name: strings.TrimPrefix(p, "/v1beta1/"), Done: true})
+	case strings.HasPrefix(p, "/v1beta1/projects/"):
+		rest := strings.TrimPrefix(p, "/v1beta1/projects/")
+		id, method, _ := strings.Cut(rest, ":")
+		id, sub, _ := strings.Cut(id, "/")
+		project := f.project(id)
+		switch {
+		case method == "" && sub == "" && req.Method == http.MethodGet:
+			return fakeJSON(http.StatusOK, project.Project)
+		case method == "" && sub == "" && req.Method == http.MethodPatch:
+			return project.patch(body, req.URL.Query().Get("updateMask"))
+		case sub == "analyticsDetails" && req.Method == http.MethodGet:
+			if project.Analytics == nil {
+				return fakeError(http.StatusNotFound, "NOT_FOUND", "the project is not linked to Google Analytics")
+			}
+			return fakeJSON(http.StatusOK, project.Analytics)
+		case method == "addGoogleAnalytics" && req.Method == http.MethodPost:
+			return project.addAnalytics(body)
+		case method == "removeAnalytics" && req.Method == http.MethodPost:
+			project.Analytics = nil
+			return fakeJSON(http.StatusOK, struct{}{})
+		}
+	}
+
+	return fakeError(http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("the fake doesn't serve %s %s", req.Method, p))
+}
+
+// project returns the state of a project, creating it on first use.
+func (f *FakeTransport) project(id string) *fakeProject {
+	if project, ok := f.state.Projects[id]; ok {
+		return project
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	project := &fakeProject{
+		Project: FirebaseProject{
+			ProjectID:     id,
+			ProjectNumber: strconv.FormatUint(uint64(h.Sum32())+100000000000, 10),
+			DisplayName:   id,
+			State:         "ACTIVE",
+		},
+	}
+	project.Project.Etag = project.etag()
+	project.Versions = []json.RawMessage{project.versioned(rawObject{}, "", "INCREMENTAL_UPDATE")}
+	f.state.Projects[id] = project
+
+	return project
+}
+
+func (p *fakeProject) etag() string {
+	return fmt.Sprintf("etag-%s-%d", p.Project.ProjectID, len(p.Versions))
+}
+
+// versioned adds to a template the metadata of the version it becomes.
+func (p *fakeProject) versioned(template rawObject, description string, updateType string) json.RawMessage {
+	template = maps.Clone(template)
+	if template == nil {
+		template = rawObject{}
+	}
+	version, _ := json.Marshal(map[string]any{
+		"versionNumber": strconv.Itoa(len(p.Versions) + 1),
+		"description":   description,
+		"updateTime":    time.Now().UTC().Format(time.RFC3339Nano),
+		"updateUser":    map[string]string{"email": FakeUpdateUser},
+		"updateOrigin":  "REST_API",
+		"updateType":    updateType,
+	})
+	template["version"] = version
+	data, _ := json.Marshal(template)
+
+	return data
+}
+
+func (p *fakeProject) getTemplate(versionNumber string) (int, http.Header, []byte) {
+	template := p.Versions[len(p.Versions)-1]
+	if versionNumber != "" {
+		n, err := strconv.Atoi(versionNumber)
+		if err != nil || n < 1 || n > len(p.Versions) {
+			return fakeError(http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("version %s doesn't exist", versionNumber))
+		}
+		template = p.Versions[n-1]
+	}
+
+	return http.StatusOK, http.Header{"Etag": {p.etag()}}, template
+}
+
+func (p *fakeProject) publish(body []byte, ifMatch string, validateOnly bool) (int, http.Header, []byte) {
+	var template rawObject
+	if err := json.Unmarshal(body, &template); err != nil {
+		return fakeError(http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+	}
+	if err := validateFakeTemplate(template); err != nil {
+		return fakeError(http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+	}
+	if ifMatch != "*" && ifMatch != p.etag() {
+		return fakeError(http.StatusPreconditionFailed, "FAILED_PRECONDITION", "the etag doesn't match the current template")
+	}
+	if validateOnly {
+		return http.StatusOK, http.Header{"Etag": {p.etag()}}, body
+	}
+
+	var version struct {
+		Description string `json:"description"`
+	}
+	_ = json.Unmarshal(template["version"], &version)
+	delete(template, "version")
+
+	p.Versions = append(p.Versions, p.versioned(template, version.Description, "INCREMENTAL_UPDATE"))
+	return p.getTemplate("")
+}
+
+func (p *fakeProject) rollback(body []byte) (int, http.Header, []byte) {
+	var req struct {
+		VersionNumber string `json:"versionNumber"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return fakeError(http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+	}
+	status, _, data := p.getTemplate(req.VersionNumber)
+	if status != http.StatusOK {
+		return status, nil, data
+	}
+
+	var template rawObject
+	_ = json.Unmarshal(data, &template)
+	delete(template, "version")
+	p.Versions = append(p.Versions, p.versioned(template, "", "ROLLBACK"))
+
+	return p.getTemplate("")
+}
+
+func (p *fakeProject) listVersions(endVersionNumber string) (int, http.Header, []byte) {
+	end := len(p.Versions)
+	if n, err := strconv.Atoi(endVersionNumber); err == nil && n < end {
+		end = n
+	}
+
+	list := RemoteConfigVersionList{}
+	for i := end - 1; i >= 0; i-- {
+		var template struct {
+			Version RemoteConfigVersion `json:"version"`
+		}
+		_ = json.Unmarshal(p.Versions[i], &template)
+		list.Versions = append(list.Versions, template.Version)
+	}
+
+	return fakeJSON(http.StatusOK, list)
+}
+
+func (p *fakeProject) patch(body []byte, updateMask string) (int, http.Header, []byte) {
+	var patch FirebaseProject
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return fakeError(http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+	}
+	if patch.Etag != "" && patch.Etag != p.Project.Etag {
+		return fakeError(http.StatusConflict, "ABORTED", "the project changed since it was read")
+	}
+
+	for _, field := range strings.Split(updateMask, ",") {
+		switch field {
+		case "displayName", "display_name":
+			p.Project.DisplayName = patch.DisplayName
+		case "annotations":
+			p.Project.Annotations = patch.Annotations
+		}
+	}
+	p.Project.Etag = fmt.Sprintf("%s-%d", p.etag(), time.Now().UnixNano())
+
+	return fakeJSON(http.StatusOK, p.Project)
+}
+
+func (p *fakeProject) addAnalytics(body []byte) (int, http.Header, []byte) {
+	var req struct {
+		AnalyticsAccountID  string `json:"analyticsAccountId"`
+		AnalyticsPropertyID string `json:"analyticsPropertyId"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return fakeError(http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+	}
+
+	details := &AnalyticsDetails{}
+	details.AnalyticsProperty.ID = req.AnalyticsPropertyID
+	if details.AnalyticsProperty.ID == "" {
+		details.AnalyticsProperty.ID = "properties/" + p.Project.ProjectNumber
+	}
+	details.AnalyticsProperty.DisplayName = p.Project.DisplayName
+	details.AnalyticsProperty.AnalyticsAccountID = req.AnalyticsAccountID
+	details.StreamMappings = []StreamMapping{}
+	p.Analytics = details
+
+	return fakeJSON(http.StatusOK, Operation{Name: "operations/mock-analytics-" + p.Project.ProjectID, Done: true})
+}
+
+// validateFakeTemplate rejects the templates the real API is most likely
+// to reject: unknown conditions and duplicate parameter keys.
+// accounts serves the Identity Toolkit account methods of a tenant.
+func (p *fakeProject) accounts(tenant string, method string, body []byte) (int, http.Header, []byte) {
+	if p.Users == nil {
+		p.Users = map[string]map[string]UserAccount{}
+	}
+	if p.Users[tenant] == nil {
+		p.Users[tenant] = map[string]UserAccount{}
+	}
+	users := p.Users[tenant]
+
+	switch method {
+	case "batchCreate":
+		var req UserImport
+		if err := json.Unmarshal(body, &req); err != nil {
+			return fakeError(http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+		}
+		var failed []UserImportError
+		for i, u := range req.Users {
+			if _, ok := users[u.LocalID]; ok && !req.AllowOverwrite {
+				failed = append(failed, UserImportError{Index: i, LocalID: u.LocalID, Message: "localId exists"})
+				continue
+			}
+			users[u.LocalID] = UserAccount{LocalID: u.LocalID, Email: u.Email, EmailVerified: u.EmailVerified, DisplayName: u.DisplayName, Disabled: u.Disabled}
+		}
+		return fakeJSON(http.StatusOK, map[string]any{"error": failed})
+	case "lookup":
+		var req struct {
+			LocalID []string `json:"localId"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			return fakeError(http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+		}
+		var found []UserAccount
+		for _, id := range req.LocalID {
+			if u, ok := users[id]; ok {
+				found = append(found, u)
+			}
+		}
+		return fakeJSON(http.StatusOK, map[string]any{"users": found})
+	case "batchDelete":
+		var req struct {
+			LocalIDs []string `json:"localIds"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			return fakeError(http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+		}
+		for _, id := range req.LocalIDs {
+			delete(users, id)
+		}
+		return fakeJSON(http.StatusOK, struct{}{})
+	}
+
+	return fakeError(http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("the fake doesn't serve accounts:%s", method))
+}
+
+func validateFakeTemplate(template rawObject) error {
+	var t RemoteConfigRead
+	data, _ := json.Marshal(template)
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+
+	conditions := make([]string, 0, len(t.Conditions))
+	for _, c := range t.Conditions {
+		if slices.Contains(conditions, c.Name) {
+			return fmt.Errorf("condition %s is declared twice", c.Name)
+		}
+		conditions = append(conditions, c.Name)
+	}
+
+	seen := map[string]bool{}
+	check := func(params map[string]RemoteConfigParameter) error {
+		for name, param := range params {
+			if seen[name] {
+				return fmt.Errorf("parameter %s is declared twice", name)
+			}
+			seen[name] = true
+			for condition := range param.ConditionalValues {
+				if !slices.Contains(conditions, condition) {
+					return fmt.Errorf("parameter %s references unknown condition %s", name, condition)
+				}
+			}
+		}
+		return nil
+	}
+	if err := check(t.Parameters); err != nil {
+		return err
+	}
+	for _, group := range t.ParameterGroups {
+		if err := check(group.Parameters); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fakeDecrypt serves Cloud KMS decryption. The fake encrypts with base64
+// only: the plaintext of a ciphertext is its base64 decoding, so the
+// ciphertext of a value is base64encode(value).
+func fakeDecrypt(body []byte) (int, http.Header, []byte) {
+	var req struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil || req.Ciphertext == "" {
+		return fakeError(http.StatusBadRequest, "INVALID_ARGUMENT", "ciphertext is required")
+	}
+
+	return fakeJSON(http.StatusOK, map[string]string{"plaintext": req.Ciphertext})
+}
+
+func fakeJSON(status int, v any) (int, http.Header, []byte) {
+	data, _ := json.Marshal(v)
+	return status, nil, data
+}
+
+func fakeError(status int, code string, message string) (int, http.Header, []byte) {
+	return fakeJSON(status, map[string]any{
+		"error": map[string]any{"code": status, "message": message, "status": code},
+	})
+}
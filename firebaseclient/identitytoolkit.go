@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultIdentityToolkitEndpoint is the Identity Toolkit API endpoint used
+// when none is set.
+const DefaultIdentityToolkitEndpoint = "https://identitytoolkit.googleapis.com"
+
+// MaxImportedUsers is the number of users a single batchCreate accepts.
+const MaxImportedUsers = 1000
+
+// ImportedUser is a user account imported with accounts:batchCreate.
+// PasswordHash and Salt are base64 encoded.
+type ImportedUser struct {
+	LocalID          string `json:"localId"`
+	Email            string `json:"email,omitempty"`
+	EmailVerified    bool   `json:"emailVerified,omitempty"`
+	DisplayName      string `json:"displayName,omitempty"`
+	PasswordHash     string `json:"passwordHash,omitempty"`
+	Salt             string `json:"salt,omitempty"`
+	Disabled         bool   `json:"disabled,omitempty"`
+	CustomAttributes string `json:"customAttributes,omitempty"`
+}
+
+// UserImport is the request of accounts:batchCreate, the users together
+// with how their password hashes were computed. SignerKey and
+// SaltSeparator are base64 encoded.
+type UserImport struct {
+	HashAlgorithm     string         `json:"hashAlgorithm,omitempty"`
+	SignerKey         string         `json:"signerKey,omitempty"`
+	SaltSeparator     string         `json:"saltSeparator,omitempty"`
+	Rounds            int64          `json:"rounds,omitempty"`
+	MemoryCost        int64          `json:"memoryCost,omitempty"`
+	CPUMemCost        int64          `json:"cpuMemCost,omitempty"`
+	Parallelization   int64          `json:"parallelization,omitempty"`
+	BlockSize         int64          `json:"blockSize,omitempty"`
+	DkLen             int64          `json:"dkLen,omitempty"`
+	PasswordHashOrder string         `json:"passwordHashOrder,omitempty"`
+	AllowOverwrite    bool           `json:"allowOverwrite,omitempty"`
+	SanityCheck       bool           `json:"sanityCheck,omitempty"`
+	Users             []ImportedUser `json:"users"`
+}
+
+// UserImportError reports a user accounts:batchCreate failed to import.
+type UserImportError struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+	LocalID string `json:"localId"`
+}
+
+// UserAccount is a user account as returned by accounts:lookup.
+type UserAccount struct {
+	LocalID       string `json:"localId"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"emailVerified"`
+	DisplayName   string `json:"displayName"`
+	Disabled      bool   `json:"disabled"`
+}
+
+// AccountsURL returns the Identity Toolkit accounts url of a project, or of
+// a tenant of the project when tenant is set.
+func (c *Client) AccountsURL(project string, tenant string) string {
+	if tenant != "" {
+		return fmt.Sprintf("%s/v1/projects/%s/tenants/%s/accounts", c.identityToolkit, project, tenant)
+	}
+
+	return fmt.Sprintf("%s/v1/projects/%s/accounts", c.identityToolkit, project)
+}
+
+// ImportUsers imports users in batches of MaxImportedUsers. It returns the
+// users the API refused, the other ones are imported.
+func (c *Client) ImportUsers(ctx context.Context, project string, tenant string, users UserImport) ([]UserImportError, error) {
+	var failed []UserImportError
+	all := users.Users
+	for start := 0; start < len(all); start += MaxImportedUsers {
+		batch := users
+		batch.Users = all[start:min(start+MaxImportedUsers, len(all))]
+
+		var resp struct {
+			Error []UserImportError `json:"error"`
+		}
+		if err := c.postAccounts(ctx, project, tenant, "batchCreate", batch, &resp); err != nil {
+			return failed, err
+		}
+		for _, e := range resp.Error {
+			// Indexes are relative to the batch.
+			e.Index += start
+			failed = append(failed, e)
+		}
+	}
+
+	return failed, nil
+}
+
+// LookupUsers returns the accounts among localIDs that exist.
+func (c *Client) LookupUsers(ctx context.Context, project string, tenant string, localIDs []string) ([]UserAccount, error) {
+	var users []UserAccount
+	for start := 0; start < len(localIDs); start += MaxImportedUsers {
+		req := struct {
+			LocalID []string `json:"localId"`
+		}{LocalID: localIDs[start:min(start+MaxImportedUsers, len(localIDs))]}
+
+		var resp struct {
+			Users []UserAccount `json:"users"`
+		}
+		if err := c.postAccounts(ctx, project, tenant, "lookup", req, &resp); err != nil {
+			return nil, err
+		}
+		users = append(users, resp.Users...)
+	}
+
+	return users, nil
+}
+
+// DeleteUsers deletes accounts, whether or not they are disabled.
+func (c *Client) DeleteUsers(ctx context.Context, project string, tenant string, localIDs []string) error {
+	for start := 0; start < len(localIDs); start += MaxImportedUsers {
+		req := struct {
+			LocalIDs []string `json:"localIds"`
+			Force    bool     `json:"force"`
+		}{LocalIDs: localIDs[start:min(start+MaxImportedUsers, len(localIDs))], Force: true}
+
+		if err := c.postAccounts(ctx, project, tenant, "batchDelete", req, &struct{}{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) postAccounts(ctx context.Context, project string, tenant string, method string, body any, target any) error {
+	u := c.AccountsURL(project, tenant) + ":" + method
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := c.NewRequest(ctx, "POST", u, bytes.NewReader(jsonData))
+	if err != nil {
+		return err
+	}
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("unable to make http request to identity toolkit: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read identity toolkit response: %w", err)
+	}
+
+	// The request holds password hashes, only the response is logged.
+	tflog.Trace(ctx, fmt.Sprintf("identity toolkit api response %s %s", u, string(bodyBytes)))
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to %s accounts on url: %s, status: %d, resp: %s", method, u, httpResp.StatusCode, string(bodyBytes))
+	}
+	if err = json.Unmarshal(bodyBytes, target); err != nil {
+		return fmt.Errorf("unable to decode %s response on url: %s \n%s, resp: %s", method, u, err, string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestImportUsersBatches(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/v1/projects/my-project/accounts:batchCreate") {
+			http.NotFound(w, r)
+			return
+		}
+		var req UserImport
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		batches = append(batches, len(req.Users))
+		mu.Unlock()
+
+		// The API reports refused users by their index in the batch.
+		var refused []UserImportError
+		for i, user := range req.Users {
+			if strings.HasPrefix(user.LocalID, "bad") {
+				refused = append(refused, UserImportError{Index: i, Message: "invalid", LocalID: user.LocalID})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": refused})
+	}))
+	defer server.Close()
+
+	c := New(WithHTTPClient(server.Client()), WithIdentityToolkitEndpoint(server.URL))
+	users := make([]ImportedUser, 2500)
+	bad := []int{5, 1500, 2499}
+	for i := range users {
+		users[i].LocalID = fmt.Sprintf("user-%d", i)
+		if slices.Contains(bad, i) {
+			users[i].LocalID = fmt.Sprintf("bad-%d", i)
+		}
+	}
+
+	failed, err := c.ImportUsers(context.Background(), "my-project", "", UserImport{HashAlgorithm: "BCRYPT", Users: users})
+	if err != nil {
+		t.Fatalf("ImportUsers() = %v", err)
+	}
+	if want := []int{MaxImportedUsers, MaxImportedUsers, 500}; !slices.Equal(batches, want) {
+		t.Errorf("batches = %v, want %v", batches, want)
+	}
+	var indexes []int
+	for _, e := range failed {
+		indexes = append(indexes, e.Index)
+		if users[e.Index].LocalID != e.LocalID {
+			t.Errorf("refused index %d is user %s, want %s", e.Index, users[e.Index].LocalID, e.LocalID)
+		}
+	}
+	if !slices.Equal(indexes, bad) {
+		t.Errorf("refused indexes = %v, want %v", indexes, bad)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultIAMCredentialsEndpoint is the IAM Service Account Credentials API
+// endpoint service accounts are impersonated through.
+const DefaultIAMCredentialsEndpoint = "https://iamcredentials.googleapis.com"
+
+// impersonatedTokenSource issues access tokens of a service account to the
+// principal of base, which needs roles/iam.serviceAccountTokenCreator on it
+// or on the first of delegates.
+type impersonatedTokenSource struct {
+	base       oauth2.TokenSource
+	httpClient *http.Client
+	target     string
+	delegates  []string
+	scopes     []string
+}
+
+// ImpersonatedTokenSource returns a token source of the target service
+// account, impersonated by the principal of base through the chain of
+// delegates. Tokens are reused until they expire.
+func ImpersonatedTokenSource(base oauth2.TokenSource, target string, delegates []string, scopes []string) oauth2.TokenSource {
+	return oauth2.ReuseTokenSource(nil, &impersonatedTokenSource{
+		base:       base,
+		httpClient: DefaultHTTPClient(),
+		target:     target,
+		delegates:  delegates,
+		scopes:     scopes,
+	})
+}
+
+func (s *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	u := fmt.Sprintf("%s/v1/projects/-/serviceAccounts/%s:generateAccessToken", DefaultIAMCredentialsEndpoint, s.target)
+
+	delegates := make([]string, 0, len(s.delegates))
+	for _, d := range s.delegates {
+		delegates = append(delegates, "projects/-/serviceAccounts/"+d)
+	}
+	jsonData, err := json.Marshal(map[string]any{
+		"delegates": delegates,
+		"scope":     s.scopes,
+		"lifetime":  "3600s",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(context.Background(), "POST", u, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	token, err := s.base.Token()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the access token impersonating %s: %w", s.target, err)
+	}
+	token.SetAuthHeader(httpReq)
+
+	httpResp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make http request to impersonate %s: %w", s.target, err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read iam credentials response: %w", err)
+	}
+
+	// The response holds the access token, it is never logged.
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to impersonate %s on url: %s, status: %d, resp: %s", s.target, u, httpResp.StatusCode, string(bodyBytes))
+	}
+
+	var resp struct {
+		AccessToken string    `json:"accessToken"`
+		ExpireTime  time.Time `json:"expireTime"`
+	}
+	if err = json.Unmarshal(bodyBytes, &resp); err != nil {
+		return nil, fmt.Errorf("unable to decode the access token of %s: %s", s.target, err)
+	}
+
+	return &oauth2.Token{AccessToken: resp.AccessToken, TokenType: "Bearer", Expiry: resp.ExpireTime}, nil
+}
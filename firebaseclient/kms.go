@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultKMSEndpoint is the Cloud KMS API endpoint used when none is set.
+const DefaultKMSEndpoint = "https://cloudkms.googleapis.com"
+
+// DecryptURL returns the Cloud KMS API url decrypting with a crypto key,
+// named projects/{project}/locations/{location}/keyRings/{ring}/cryptoKeys/{key}.
+func (c *Client) DecryptURL(cryptoKey string) string {
+	return fmt.Sprintf("%s/v1/%s:decrypt", c.kms, cryptoKey)
+}
+
+// Decrypt returns the plaintext of a base64 encoded ciphertext encrypted
+// with a Cloud KMS crypto key. The principal of the client needs
+// roles/cloudkms.cryptoKeyDecrypter on the key.
+func (c *Client) Decrypt(ctx context.Context, cryptoKey string, ciphertext string) ([]byte, error) {
+	u := c.DecryptURL(cryptoKey)
+
+	jsonData, err := json.Marshal(map[string]string{"ciphertext": ciphertext})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := c.NewRequest(ctx, "POST", u, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make http request to decrypt: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read kms response: %w", err)
+	}
+
+	// The response holds the plaintext, it is never logged.
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to decrypt on url: %s, status: %d, resp: %s", u, httpResp.StatusCode, string(bodyBytes))
+	}
+
+	var resp struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err = json.Unmarshal(bodyBytes, &resp); err != nil {
+		return nil, fmt.Errorf("unable to decode the plaintext on url: %s: %s", u, err)
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the plaintext on url: %s: %s", u, err)
+	}
+
+	return plaintext, nil
+}
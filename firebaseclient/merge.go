@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"encoding/json"
+)
+
+// Fields modeled by the client at every level of a template. Anything else
+// found in a live template is carried over untouched on publish, so fields
+// Firebase adds server side are not stripped.
+var (
+	modeledTemplateFields       = []string{"conditions", "parameters", "parameterGroups", "parameter_groups", "version"}
+	modeledParameterFields      = []string{"defaultValue", "conditionalValues", "description", "valueType"}
+	modeledParameterGroupFields = []string{"description", "parameters"}
+)
+
+type rawObject = map[string]json.RawMessage
+
+// MarshalJSON encodes the update, merging in the fields of Preserve the
+// client doesn't model.
+func (u RemoteConfigUpdate) MarshalJSON() ([]byte, error) {
+	type plain RemoteConfigUpdate
+	modeled, err := json.Marshal(plain(u))
+	if err != nil || len(u.Preserve) == 0 {
+		return modeled, err
+	}
+
+	return mergeUnmodeled(u.Preserve, modeled)
+}
+
+// mergeUnmodeled overlays the modeled template on the unmodeled fields of
+// the live template.
+func mergeUnmodeled(live json.RawMessage, modeled []byte) ([]byte, error) {
+	var liveTemplate, template rawObject
+	if err := json.Unmarshal(live, &liveTemplate); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(modeled, &template); err != nil {
+		return nil, err
+	}
+
+	// Parameters are matched by key wherever they live, so moving one in or
+	// out of a group keeps its unmodeled fields.
+	liveParams := rawObject{}
+	collectParameters(liveTemplate["parameters"], liveParams)
+	var liveGroups map[string]rawObject
+	_ = json.Unmarshal(liveTemplate["parameterGroups"], &liveGroups)
+	for _, group := range liveGroups {
+		collectParameters(group["parameters"], liveParams)
+	}
+
+	var err error
+	if template["parameters"], err = mergeParameters(liveParams, template["parameters"]); err != nil {
+		return nil, err
+	}
+
+	var groups map[string]rawObject
+	if err := json.Unmarshal(template["parameterGroups"], &groups); err == nil && groups != nil {
+		for name, group := range groups {
+			if group["parameters"], err = mergeParameters(liveParams, group["parameters"]); err != nil {
+				return nil, err
+			}
+			groups[name] = overlay(liveGroups[name], group, modeledParameterGroupFields)
+		}
+		if template["parameterGroups"], err = json.Marshal(groups); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(overlay(liveTemplate, template, modeledTemplateFields))
+}
+
+func collectParameters(raw json.RawMessage, into rawObject) {
+	var params rawObject
+	_ = json.Unmarshal(raw, &params)
+	for k, v := range params {
+		into[k] = v
+	}
+}
+
+func mergeParameters(liveParams rawObject, raw json.RawMessage) (json.RawMessage, error) {
+	var params map[string]rawObject
+	if err := json.Unmarshal(raw, &params); err != nil || params == nil {
+		return raw, nil
+	}
+
+	for k, param := range params {
+		var live rawObject
+		_ = json.Unmarshal(liveParams[k], &live)
+		params[k] = overlay(live, param, modeledParameterFields)
+	}
+
+	return json.Marshal(params)
+}
+
+// overlay returns the unmodeled fields of live with modeled on top. A
+// modeled field set to null is not managed and keeps its live value.
+func overlay(live rawObject, modeled rawObject, modeledFields []string) rawObject {
+	merged := rawObject{}
+	for k, v := range live {
+		merged[k] = v
+	}
+	for _, k := range modeledFields {
+		delete(merged, k)
+	}
+	for k, v := range modeled {
+		if string(v) == "null" {
+			if liveValue, ok := live[k]; ok {
+				merged[k] = liveValue
+				continue
+			}
+		}
+		merged[k] = v
+	}
+
+	return merged
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRemoteConfigUpdateMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	update := RemoteConfigUpdate{
+		Conditions: []RemoteConfigCondition{{Name: "ios", Expression: "device.os == 'ios'"}},
+		Parameters: map[string]RemoteConfigParameter{
+			"welcome": {DefaultValue: ConfigValue{Value: "hello"}, ValueType: "STRING"},
+		},
+		ParameterGroups: map[string]RemoteConfigParameterGroup{
+			"onboarding": {Description: "Onboarding", Parameters: map[string]RemoteConfigParameter{
+				"moved": {DefaultValue: ConfigValue{Value: "1"}, ValueType: "NUMBER"},
+			}},
+		},
+		Preserve: []byte(`{
+			"etag": "ignored",
+			"rolloutMetadata": {"id": "r1"},
+			"version": {"versionNumber": "7"},
+			"conditions": [{"name": "live"}],
+			"parameters": {
+				"welcome": {"defaultValue": {"value": "live"}, "description": "live", "serverSide": true},
+				"moved": {"defaultValue": {"value": "0"}, "tags": ["a"]},
+				"dropped": {"defaultValue": {"value": "x"}}
+			},
+			"parameterGroups": {"onboarding": {"description": "live", "owner": "growth"}}
+		}`),
+	}
+
+	body, err := json.Marshal(update)
+	if err != nil {
+		t.Fatalf("Marshal() = %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to decode %s: %v", body, err)
+	}
+
+	want := map[string]any{
+		"etag":            "ignored",
+		"rolloutMetadata": map[string]any{"id": "r1"},
+		"conditions":      []any{map[string]any{"name": "ios", "expression": "device.os == 'ios'"}},
+		"parameters": map[string]any{
+			"welcome": map[string]any{
+				"defaultValue":      map[string]any{"value": "hello"},
+				"conditionalValues": nil,
+				"description":       "",
+				"valueType":         "STRING",
+				"serverSide":        true,
+			},
+		},
+		"parameterGroups": map[string]any{
+			"onboarding": map[string]any{
+				"description": "Onboarding",
+				"owner":       "growth",
+				"parameters": map[string]any{
+					"moved": map[string]any{
+						"defaultValue":      map[string]any{"value": "1"},
+						"conditionalValues": nil,
+						"description":       "",
+						"valueType":         "NUMBER",
+						"tags":              []any{"a"},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Marshal() = %s", body)
+	}
+}
+
+func TestRemoteConfigUpdateMarshalJSONWithoutPreserve(t *testing.T) {
+	t.Parallel()
+
+	body, err := json.Marshal(RemoteConfigUpdate{Parameters: map[string]RemoteConfigParameter{}})
+	if err != nil {
+		t.Fatalf("Marshal() = %v", err)
+	}
+	if want := `{"conditions":null,"parameters":{},"parameterGroups":null}`; string(body) != want {
+		t.Errorf("Marshal() = %s, want %s", body, want)
+	}
+}
+
+func TestOverlayKeepsLiveValueForNull(t *testing.T) {
+	t.Parallel()
+
+	live := rawObject{"conditionalValues": json.RawMessage(`{"ios": {"value": "1"}}`), "extra": json.RawMessage(`1`)}
+	modeled := rawObject{"conditionalValues": json.RawMessage(`null`), "description": json.RawMessage(`null`)}
+
+	got := overlay(live, modeled, modeledParameterFields)
+	if string(got["conditionalValues"]) != `{"ios": {"value": "1"}}` {
+		t.Errorf("conditionalValues = %s, want the live value", got["conditionalValues"])
+	}
+	if string(got["description"]) != "null" || string(got["extra"]) != "1" {
+		t.Errorf("overlay() = %v", got)
+	}
+}
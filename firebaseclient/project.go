@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// FirebaseProject is a project as returned by the Firebase Management API.
+type FirebaseProject struct {
+	ProjectID     string            `json:"projectId,omitempty"`
+	ProjectNumber string            `json:"projectNumber,omitempty"`
+	DisplayName   string            `json:"displayName,omitempty"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+	State         string            `json:"state,omitempty"`
+	Etag          string            `json:"etag,omitempty"`
+}
+
+// ProjectURL returns the Firebase Management API url of a project.
+func (c *Client) ProjectURL(project string) string {
+	return fmt.Sprintf("%s/v1beta1/projects/%s", c.management, project)
+}
+
+// GetProject fetches a Firebase project.
+func (c *Client) GetProject(ctx context.Context, project string) (*FirebaseProject, error) {
+	u := c.ProjectURL(project)
+
+	httpReq, err := c.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doProject(ctx, httpReq)
+}
+
+// PatchProject updates the fields of a project named by updateMask, guarded
+// by the etag of the project when set.
+func (c *Client) PatchProject(ctx context.Context, project string, patch FirebaseProject, updateMask []string) (*FirebaseProject, error) {
+	u := c.ProjectURL(project) + "?" + url.Values{"updateMask": {strings.Join(updateMask, ",")}}.Encode()
+
+	jsonData, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := c.NewRequest(ctx, "PATCH", u, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	tflog.Trace(ctx, fmt.Sprintf("prepare to patch project url: %s payload: %s", u, string(jsonData)))
+
+	return c.doProject(ctx, httpReq)
+}
+
+func (c *Client) doProject(ctx context.Context, httpReq *http.Request) (*FirebaseProject, error) {
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make http request to firebase: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read firebase response: %w", err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("firebase api response %s %s", httpReq.URL, string(bodyBytes)))
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to %s project on url: %s, status: %d, resp: %s", httpReq.Method, httpReq.URL, httpResp.StatusCode, string(bodyBytes))
+	}
+
+	var target FirebaseProject
+	if err = json.Unmarshal(bodyBytes, &target); err != nil {
+		return nil, fmt.Errorf("unable to decode project on url: %s \n%s, resp: %s", httpReq.URL, err, string(bodyBytes))
+	}
+
+	return &target, nil
+}
+
+// AnalyticsDetails is the Google Analytics linkage of a project.
+type AnalyticsDetails struct {
+	AnalyticsProperty struct {
+		ID                 string `json:"id"`
+		DisplayName        string `json:"displayName"`
+		AnalyticsAccountID string `json:"analyticsAccountId"`
+	} `json:"analyticsProperty"`
+	StreamMappings []StreamMapping `json:"streamMappings"`
+}
+
+// StreamMapping links a Firebase app to a Google Analytics data stream.
+type StreamMapping struct {
+	App           string `json:"app"`
+	StreamID      string `json:"streamId"`
+	MeasurementID string `json:"measurementId"`
+}
+
+// GetAnalyticsDetails fetches the Google Analytics linkage of a project.
+func (c *Client) GetAnalyticsDetails(ctx context.Context, project string) (*AnalyticsDetails, error) {
+	u := c.ProjectURL(project) + "/analyticsDetails"
+
+	httpReq, err := c.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make http request to firebase: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read firebase response: %w", err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("firebase api response %s %s", u, string(bodyBytes)))
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("analytics details on url: %s: %w", u, ErrNotFound)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read analytics details on url: %s, status: %d, resp: %s", u, httpResp.StatusCode, string(bodyBytes))
+	}
+
+	var target AnalyticsDetails
+	if err = json.Unmarshal(bodyBytes, &target); err != nil {
+		return nil, fmt.Errorf("unable to decode analytics details on url: %s \n%s, resp: %s", u, err, string(bodyBytes))
+	}
+
+	return &target, nil
+}
+
+// Operation is a Firebase Management API long running operation.
+type Operation struct {
+	Name  string `json:"name"`
+	Done  bool   `json:"done"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	Response json.RawMessage `json:"response"`
+}
+
+// AddGoogleAnalytics links a project to Google Analytics, either to the
+// existing property analyticsPropertyID or to a new property provisioned
+// in analyticsAccountID. It waits for the link to complete.
+func (c *Client) AddGoogleAnalytics(ctx context.Context, project string, analyticsAccountID string, analyticsPropertyID string) (*AnalyticsDetails, error) {
+	body := map[string]string{}
+	if analyticsPropertyID != "" {
+		body["analyticsPropertyId"] = analyticsPropertyID
+	} else {
+		body["analyticsAccountId"] = analyticsAccountID
+	}
+
+	op, err := c.postOperation(ctx, c.ProjectURL(project)+":addGoogleAnalytics", body)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.WaitOperation(ctx, op); err != nil {
+		return nil, err
+	}
+
+	return c.GetAnalyticsDetails(ctx, project)
+}
+
+// RemoveAnalytics unlinks a project from its Google Analytics property.
+func (c *Client) RemoveAnalytics(ctx context.Context, project string, analyticsPropertyID string) error {
+	u := c.ProjectURL(project) + ":removeAnalytics"
+
+	jsonData, err := json.Marshal(map[string]string{"analyticsPropertyId": analyticsPropertyID})
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := c.NewRequest(ctx, "POST", u, bytes.NewReader(jsonData))
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("unable to make http request to firebase: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read firebase response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to remove analytics on url: %s, status: %d, resp: %s", u, httpResp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// postOperation posts body to a method starting a long running operation.
+func (c *Client) postOperation(ctx context.Context, u string, body any) (*Operation, error) {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := c.NewRequest(ctx, "POST", u, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doOperation(ctx, httpReq)
+}
+
+// WaitOperation polls an operation until it is done, returning its
+// response.
+func (c *Client) WaitOperation(ctx context.Context, op *Operation) (json.RawMessage, error) {
+	delay := time.Second
+	for !op.Done {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		if delay < 10*time.Second {
+			delay *= 2
+		}
+
+		httpReq, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/v1beta1/%s", c.management, op.Name), nil)
+		if err != nil {
+			return nil, err
+		}
+		if op, err = c.doOperation(ctx, httpReq); err != nil {
+			return nil, err
+		}
+	}
+
+	if op.Error != nil {
+		return nil, fmt.Errorf("operation %s failed with code %d: %s", op.Name, op.Error.Code, op.Error.Message)
+	}
+
+	return op.Response, nil
+}
+
+func (c *Client) doOperation(ctx context.Context, httpReq *http.Request) (*Operation, error) {
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make http request to firebase: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read firebase response: %w", err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("firebase api response %s %s", httpReq.URL, string(bodyBytes)))
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to %s operation on url: %s, status: %d, resp: %s", httpReq.Method, httpReq.URL, httpResp.StatusCode, string(bodyBytes))
+	}
+
+	var op Operation
+	if err = json.Unmarshal(bodyBytes, &op); err != nil {
+		return nil, fmt.Errorf("unable to decode operation on url: %s \n%s, resp: %s", httpReq.URL, err, string(bodyBytes))
+	}
+
+	return &op, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// redactedValue replaces the default values of sensitive parameters in
+// logged templates.
+const redactedValue = "(redacted)"
+
+type sensitiveParametersKey struct{}
+
+// WithSensitiveParameters returns a context with which the default values
+// of the named parameters are redacted from the Remote Config templates
+// logged or returned in errors, e.g. the plaintexts of encrypted values.
+func WithSensitiveParameters(ctx context.Context, names ...string) context.Context {
+	if len(names) == 0 {
+		return ctx
+	}
+	sensitive := make(map[string]bool, len(names))
+	for _, name := range names {
+		sensitive[name] = true
+	}
+
+	return context.WithValue(ctx, sensitiveParametersKey{}, sensitive)
+}
+
+// loggable returns a Remote Config request or response body as it may be
+// logged: with the values of the sensitive parameters of ctx redacted, or
+// only its size when it can't be redacted.
+func loggable(ctx context.Context, body []byte) string {
+	sensitive, _ := ctx.Value(sensitiveParametersKey{}).(map[string]bool)
+	if len(sensitive) == 0 {
+		return string(body)
+	}
+
+	var template map[string]any
+	if err := json.Unmarshal(body, &template); err != nil {
+		return fmt.Sprintf("(%d bytes, redacted)", len(body))
+	}
+	redact := func(params any) {
+		members, _ := params.(map[string]any)
+		for name, param := range members {
+			p, ok := param.(map[string]any)
+			if !ok || !sensitive[name] {
+				continue
+			}
+			p["defaultValue"] = map[string]any{"value": redactedValue}
+			// Conditional values may serve the value too.
+			delete(p, "conditionalValues")
+		}
+	}
+	redact(template["parameters"])
+	groups, _ := template["parameterGroups"].(map[string]any)
+	for _, group := range groups {
+		if g, ok := group.(map[string]any); ok {
+			redact(g["parameters"])
+		}
+	}
+	redacted, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Sprintf("(%d bytes, redacted)", len(body))
+	}
+
+	return string(redacted)
+}
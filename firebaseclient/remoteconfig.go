@@ -0,0 +1,500 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ConfigValue is a parameter value. Only one of its fields is sent, Value
+// being the default when no other kind of value is set.
+type ConfigValue struct {
+	Value                string                `json:"value"`
+	PersonalizationValue *PersonalizationValue `json:"personalizationValue,omitempty"`
+	RolloutValue         *RolloutValue         `json:"rolloutValue,omitempty"`
+	UseInAppDefault      bool                  `json:"useInAppDefault,omitempty"`
+}
+
+// PersonalizationValue links a conditional value to a Personalization.
+type PersonalizationValue struct {
+	PersonalizationID string `json:"personalizationId"`
+}
+
+// RolloutValue serves Value to Percent percent of the users targeted by a
+// Rollout.
+type RolloutValue struct {
+	RolloutID string `json:"rolloutId"`
+	Value     string `json:"value"`
+	Percent   int64  `json:"percent"`
+}
+
+func (v ConfigValue) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.UseInAppDefault:
+		return json.Marshal(struct {
+			UseInAppDefault bool `json:"useInAppDefault"`
+		}{true})
+	case v.PersonalizationValue != nil:
+		return json.Marshal(struct {
+			PersonalizationValue *PersonalizationValue `json:"personalizationValue"`
+		}{v.PersonalizationValue})
+	case v.RolloutValue != nil:
+		return json.Marshal(struct {
+			RolloutValue *RolloutValue `json:"rolloutValue"`
+		}{v.RolloutValue})
+	default:
+		return json.Marshal(struct {
+			Value string `json:"value"`
+		}{v.Value})
+	}
+}
+
+// RemoteConfigParameter is a single parameter. A nil ConditionalValues
+// leaves the live conditional values untouched on publish.
+type RemoteConfigParameter struct {
+	DefaultValue      ConfigValue            `json:"defaultValue"`
+	ConditionalValues map[string]ConfigValue `json:"conditionalValues"`
+	Description       string                 `json:"description"`
+	ValueType         string                 `json:"valueType"`
+}
+
+type RemoteConfigCondition struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	TagColor   string `json:"tagColor,omitempty"`
+}
+
+// ConditionTagColors are the colors the console can display a condition with.
+var ConditionTagColors = []string{
+	"BLUE", "BROWN", "CYAN", "DEEP_ORANGE", "GREEN", "INDIGO",
+	"LIME", "ORANGE", "PINK", "PURPLE", "TEAL",
+}
+
+type RemoteConfigParameterGroup struct {
+	Description string                           `json:"description"`
+	Parameters  map[string]RemoteConfigParameter `json:"parameters"`
+}
+
+type RemoteConfigVersion struct {
+	VersionNumber string    `json:"versionNumber"`
+	Description   string    `json:"description"`
+	UpdateTime    time.Time `json:"updateTime"`
+	UpdateUser    struct {
+		Email string `json:"email"`
+	} `json:"updateUser"`
+	UpdateOrigin string `json:"updateOrigin"`
+	UpdateType   string `json:"updateType"`
+}
+
+type RemoteConfigVersionList struct {
+	Versions      []RemoteConfigVersion `json:"versions"`
+	NextPageToken string                `json:"nextPageToken"`
+}
+
+type RemoteConfigRead struct {
+	Conditions      []RemoteConfigCondition               `json:"conditions"`
+	Parameters      map[string]RemoteConfigParameter      `json:"parameters"`
+	ParameterGroups map[string]RemoteConfigParameterGroup `json:"parameterGroups"`
+	Version         RemoteConfigVersion                   `json:"version"`
+
+	// Raw is the template exactly as returned by the API.
+	Raw json.RawMessage `json:"-"`
+}
+
+// RemoteConfigUpdate is a template to publish. A nil Conditions leaves the
+// live conditions untouched.
+type RemoteConfigUpdate struct {
+	Conditions      []RemoteConfigCondition               `json:"conditions"`
+	Parameters      map[string]RemoteConfigParameter      `json:"parameters"`
+	ParameterGroups map[string]RemoteConfigParameterGroup `json:"parameterGroups"`
+	Version         *RemoteConfigVersionUpdate            `json:"version,omitempty"`
+
+	// Preserve is a live template whose unmodeled fields are published
+	// along with the update.
+	Preserve json.RawMessage `json:"-"`
+}
+
+// RemoteConfigVersionUpdate holds the version fields that can be set on publish.
+type RemoteConfigVersionUpdate struct {
+	Description string `json:"description,omitempty"`
+}
+
+// Namespaces of Remote Config templates, firebase for client apps and
+// firebase-server for server side templates.
+const (
+	NamespaceFirebase       = "firebase"
+	NamespaceFirebaseServer = "firebase-server"
+)
+
+// RemoteConfigNamespaces lists the namespaces a project has a template in.
+var RemoteConfigNamespaces = []string{NamespaceFirebase, NamespaceFirebaseServer}
+
+// TemplateRef returns what the Remote Config methods take in place of a
+// project ID to address the template of a namespace. The template of the
+// firebase namespace is addressed by the project ID alone.
+func TemplateRef(project string, namespace string) string {
+	if namespace == "" || namespace == NamespaceFirebase {
+		return project
+	}
+
+	return project + "/namespaces/" + namespace
+}
+
+// RemoteConfigURL returns the Remote Config template url of a project, or
+// of a namespace of a project given a TemplateRef.
+func (c *Client) RemoteConfigURL(project string) string {
+	if id, namespace, ok := strings.Cut(project, "/namespaces/"); ok {
+		return fmt.Sprintf("%s/v1/projects/%s/namespaces/%s/serverRemoteConfig", c.endpoint, id, namespace)
+	}
+
+	return fmt.Sprintf("%s/v1/projects/%s/remoteConfig", c.endpoint, project)
+}
+
+// GetRemoteConfig fetches the Remote Config template of a project together
+// with its etag. When versionNumber is not empty the template of that
+// specific version is returned instead of the active one.
+func (c *Client) GetRemoteConfig(ctx context.Context, project string, versionNumber string) (*RemoteConfigRead, string, error) {
+	u := c.RemoteConfigURL(project)
+	if versionNumber != "" {
+		u += "?" + url.Values{"versionNumber": {versionNumber}}.Encode()
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("refresh resource data from %s", u))
+	httpReq, err := c.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to make http request to read config from firebase: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to read firebase response: %w", err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("firebase api response %s %s", u, loggable(ctx, bodyBytes)))
+	tflog.Trace(ctx, fmt.Sprintf("firebase api header %v", httpResp.Header))
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unable to read remote config on url: %s, status: %d, resp: %s", u, httpResp.StatusCode, loggable(ctx, bodyBytes))
+	}
+
+	var target RemoteConfigRead
+	if err = json.Unmarshal(bodyBytes, &target); err != nil {
+		return nil, "", fmt.Errorf("unable to decode remote config on url: %s \n%s, resp: %s", u, err, loggable(ctx, bodyBytes))
+	}
+
+	if httpResp.Header.Get("Etag") == "" {
+		return nil, "", fmt.Errorf("etag header is missing in the response: %s %s", loggable(ctx, bodyBytes), httpResp.Header)
+	}
+	target.Raw = bodyBytes
+
+	return &target, httpResp.Header.Get("ETag"), nil
+}
+
+// PublishRemoteConfig publishes a template over the one matching etag, "*"
+// matching any template. It returns the published template and its etag.
+func (c *Client) PublishRemoteConfig(ctx context.Context, project string, etag string, payload RemoteConfigUpdate) (*RemoteConfigRead, string, error) {
+	u := c.RemoteConfigURL(project)
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, "", err
+	}
+
+	httpReq, err := c.NewRequest(ctx, "PUT", u, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, "", err
+	}
+	tflog.Trace(ctx, fmt.Sprintf("prepare to update remote config url: %s etag: %s payload: %s", u, etag, loggable(ctx, jsonData)))
+	httpReq.Header.Set("If-Match", etag)
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to make http request to update config to firebase: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to read firebase response: %w", err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("firebase api response %s %s", u, loggable(ctx, bodyBytes)))
+	tflog.Trace(ctx, fmt.Sprintf("firebase api header %v", httpResp.Header))
+
+	if httpResp.StatusCode == http.StatusConflict || httpResp.StatusCode == http.StatusPreconditionFailed {
+		return nil, "", fmt.Errorf("%w: %s", ErrEtagMismatch, loggable(ctx, bodyBytes))
+	}
+
+	var target RemoteConfigRead
+	if err = json.Unmarshal(bodyBytes, &target); err != nil {
+		return nil, "", fmt.Errorf("unable to create remote config on url: %s \n%s, resp: %s", u, err, loggable(ctx, bodyBytes))
+	}
+
+	if httpResp.Header.Get("Etag") == "" {
+		return nil, "", fmt.Errorf("cannot write to firebase:\n%s", loggable(ctx, bodyBytes))
+	}
+	target.Raw = bodyBytes
+
+	return &target, httpResp.Header.Get("ETag"), nil
+}
+
+// ErrEtagMismatch is returned by PublishRemoteConfig when the template
+// changed since the etag it was given.
+var ErrEtagMismatch = errors.New("remote config template changed since it was read")
+
+// maxModifyAttempts bounds the read-modify-write cycles of
+// ModifyRemoteConfig.
+const maxModifyAttempts = 5
+
+// ModifyRemoteConfig publishes the live template of a project as changed by
+// modify, starting over from a fresh template when another publish wins the
+// race. Fields the client doesn't model are published as they are live.
+func (c *Client) ModifyRemoteConfig(ctx context.Context, project string, modify func(update *RemoteConfigUpdate) error) (*RemoteConfigRead, string, error) {
+	return c.ModifyRemoteConfigFrom(ctx, project, nil, "", modify)
+}
+
+// ModifyRemoteConfigFrom is ModifyRemoteConfig starting from a template
+// already known to be live at etag, e.g. the one the previous publish
+// returned, which saves reading it. modify may change its maps. A nil
+// template is read first.
+func (c *Client) ModifyRemoteConfigFrom(ctx context.Context, project string, known *RemoteConfigRead, knownEtag string, modify func(update *RemoteConfigUpdate) error) (*RemoteConfigRead, string, error) {
+	for attempt := 1; ; attempt++ {
+		ctx := WithAttempt(ctx, attempt)
+		live, etag := known, knownEtag
+		known = nil
+		if live == nil {
+			var err error
+			if live, etag, err = c.GetRemoteConfig(ctx, project, ""); err != nil {
+				return nil, "", err
+			}
+		}
+
+		update := RemoteConfigUpdate{
+			Conditions:      live.Conditions,
+			Parameters:      live.Parameters,
+			ParameterGroups: live.ParameterGroups,
+			Preserve:        live.Raw,
+		}
+		if update.Parameters == nil {
+			update.Parameters = map[string]RemoteConfigParameter{}
+		}
+		if update.ParameterGroups == nil {
+			update.ParameterGroups = map[string]RemoteConfigParameterGroup{}
+		}
+		if err := modify(&update); err != nil {
+			return nil, "", err
+		}
+		if err := checkConditionReferences(&update); err != nil {
+			return nil, "", err
+		}
+
+		target, newEtag, err := c.PublishRemoteConfig(ctx, project, etag, update)
+		if errors.Is(err, ErrEtagMismatch) && attempt < maxModifyAttempts {
+			tflog.Debug(ctx, fmt.Sprintf("remote config of project %s changed during attempt %d, start over", project, attempt))
+			continue
+		}
+
+		return target, newEtag, err
+	}
+}
+
+// ErrUnknownCondition is returned by ModifyRemoteConfig when the modified
+// template has conditional values for conditions it doesn't declare.
+var ErrUnknownCondition = errors.New("conditional values reference undeclared conditions")
+
+// checkConditionReferences checks that every conditional value of a
+// template is for one of its conditions. Changes made separately to
+// conditions and parameters only meet in the merged template, so this is
+// the first place a dangling reference can be seen.
+func checkConditionReferences(update *RemoteConfigUpdate) error {
+	declared := make(map[string]bool, len(update.Conditions))
+	for _, c := range update.Conditions {
+		declared[c.Name] = true
+	}
+
+	var dangling []string
+	check := func(params map[string]RemoteConfigParameter) {
+		for name, param := range params {
+			for condition := range param.ConditionalValues {
+				if !declared[condition] {
+					dangling = append(dangling, fmt.Sprintf("parameter %s uses condition %s", name, condition))
+				}
+			}
+		}
+	}
+	check(update.Parameters)
+	for _, group := range update.ParameterGroups {
+		check(group.Parameters)
+	}
+	if len(dangling) == 0 {
+		return nil
+	}
+	slices.Sort(dangling)
+
+	return fmt.Errorf("%w: %s", ErrUnknownCondition, strings.Join(dangling, ", "))
+}
+
+// ErrInvalidTemplate is returned by ValidateRemoteConfig when the API
+// rejects the template.
+var ErrInvalidTemplate = errors.New("invalid remote config template")
+
+// ValidateRemoteConfig checks a template with the API without publishing
+// it. Templates the API rejects return an error wrapping ErrInvalidTemplate.
+func (c *Client) ValidateRemoteConfig(ctx context.Context, project string, payload RemoteConfigUpdate) error {
+	u := c.RemoteConfigURL(project) + "?" + url.Values{"validateOnly": {"true"}}.Encode()
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := c.NewRequest(ctx, "PUT", u, bytes.NewReader(jsonData))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("If-Match", "*")
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("unable to make http request to validate config: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read firebase response: %w", err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("firebase api response %s %s", u, loggable(ctx, bodyBytes)))
+
+	switch {
+	case httpResp.StatusCode == http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", ErrInvalidTemplate, loggable(ctx, bodyBytes))
+	case httpResp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unable to validate remote config on url: %s, status: %d, resp: %s", u, httpResp.StatusCode, loggable(ctx, bodyBytes))
+	}
+
+	return nil
+}
+
+// ListVersionsSince returns the versions published after sinceVersion up to
+// and including endVersion, newest first.
+func (c *Client) ListVersionsSince(ctx context.Context, project string, sinceVersion string, endVersion string) ([]RemoteConfigVersion, error) {
+	since, err := strconv.ParseInt(sinceVersion, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version number %q: %w", sinceVersion, err)
+	}
+
+	var versions []RemoteConfigVersion
+	pageToken := ""
+	for {
+		query := url.Values{
+			"pageSize":         {"100"},
+			"endVersionNumber": {endVersion},
+		}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		u := c.RemoteConfigURL(project) + ":listVersions?" + query.Encode()
+
+		httpReq, err := c.NewRequest(ctx, "GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		httpResp, err := c.Do(httpReq)
+		if err != nil {
+			return nil, fmt.Errorf("unable to make http request to list versions from firebase: %w", err)
+		}
+
+		bodyBytes, err := io.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read firebase response: %w", err)
+		}
+
+		tflog.Trace(ctx, fmt.Sprintf("firebase api response %s %s", u, string(bodyBytes)))
+
+		if httpResp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unable to list versions on url: %s, status: %d, resp: %s", u, httpResp.StatusCode, string(bodyBytes))
+		}
+
+		var page RemoteConfigVersionList
+		if err = json.Unmarshal(bodyBytes, &page); err != nil {
+			return nil, fmt.Errorf("unable to decode versions on url: %s \n%s, resp: %s", u, err, string(bodyBytes))
+		}
+
+		for _, v := range page.Versions {
+			number, err := strconv.ParseInt(v.VersionNumber, 10, 64)
+			if err != nil || number <= since {
+				// Versions are listed newest first, everything else is older.
+				return versions, nil
+			}
+			versions = append(versions, v)
+		}
+
+		if page.NextPageToken == "" {
+			return versions, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// RollbackRemoteConfig publishes a copy of the template of versionNumber as
+// a new version. It returns the published template and its etag.
+func (c *Client) RollbackRemoteConfig(ctx context.Context, project string, versionNumber string) (*RemoteConfigRead, string, error) {
+	u := c.RemoteConfigURL(project) + ":rollback"
+
+	jsonData, err := json.Marshal(map[string]string{"versionNumber": versionNumber})
+	if err != nil {
+		return nil, "", err
+	}
+
+	httpReq, err := c.NewRequest(ctx, "POST", u, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, "", err
+	}
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to make http request to rollback config: %w", err)
+	}
+
+	defer httpResp.Body.Close()
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to read firebase response: %w", err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("firebase api response %s %s", u, loggable(ctx, bodyBytes)))
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unable to rollback remote config on url: %s, status: %d, resp: %s", u, httpResp.StatusCode, loggable(ctx, bodyBytes))
+	}
+
+	var target RemoteConfigRead
+	if err = json.Unmarshal(bodyBytes, &target); err != nil {
+		return nil, "", fmt.Errorf("unable to decode remote config on url: %s \n%s, resp: %s", u, err, loggable(ctx, bodyBytes))
+	}
+	target.Raw = bodyBytes
+
+	return &target, httpResp.Header.Get("ETag"), nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ErrUntrustedURL is returned for raw API calls to urls the access token of
+// the client must not be sent to.
+var ErrUntrustedURL = errors.New("only https urls of googleapis.com are allowed")
+
+// CheckRestPath checks the path of a raw API call: relative, or an absolute
+// https url of a Google API. Requests carry the access token of the client,
+// which must never reach another host nor travel unencrypted.
+func CheckRestPath(path string) error {
+	u, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if !u.IsAbs() && u.Host == "" {
+		return nil
+	}
+	host := u.Hostname()
+	if u.Scheme != "https" || u.User != nil || (host != "googleapis.com" && !strings.HasSuffix(host, ".googleapis.com")) {
+		return fmt.Errorf("%s: %w", path, ErrUntrustedURL)
+	}
+
+	return nil
+}
+
+// RestURL resolves the path of a raw API call. Absolute https urls of
+// Google APIs are kept, other paths are relative to the Firebase Management
+// API endpoint.
+func (c *Client) RestURL(path string) (string, error) {
+	if err := CheckRestPath(path); err != nil {
+		return "", err
+	}
+	if u, _ := url.Parse(path); u.IsAbs() {
+		return path, nil
+	}
+
+	return c.management + "/" + strings.TrimPrefix(path, "/"), nil
+}
+
+// CallAPI sends a raw JSON request to a Google API and returns the response
+// body, for API surfaces the client doesn't model. A nil body sends none.
+func (c *Client) CallAPI(ctx context.Context, method string, path string, body []byte) ([]byte, error) {
+	u, err := c.RestURL(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := c.NewRequest(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make http request %s %s: %w", method, u, err)
+	}
+	defer httpResp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read api response: %w", err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("api response %s %s %s", method, u, string(bodyBytes)))
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s %s: %w", method, u, ErrNotFound)
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		return nil, fmt.Errorf("unable to %s on url: %s, status: %d, resp: %s", method, u, httpResp.StatusCode, string(bodyBytes))
+	}
+
+	return bodyBytes, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package firebaseclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans of the client.
+const tracerName = "terraform-provider-firebaseextra/firebaseclient"
+
+// WithTracerProvider records every API call as a span of a tracer of tp.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) {
+		if tp != nil {
+			c.tracer = tp.Tracer(tracerName)
+		}
+	}
+}
+
+type attemptKey struct{}
+
+// WithAttempt returns a context annotating the spans of the API calls made
+// with it with the attempt, from 1, of the operation retrying them.
+func WithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// traced sends a request within a span describing the API call: method,
+// url, project, attempt, status and the etags sent and received.
+func (c *Client) traced(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.full", req.URL.String()),
+		attribute.String("server.address", req.URL.Host),
+	}
+	if project := urlProject(req.URL.Path); project != "" {
+		attributes = append(attributes, attribute.String("firebase.project", project))
+	}
+	if attempt, ok := req.Context().Value(attemptKey{}).(int); ok {
+		attributes = append(attributes, attribute.Int("firebase.attempt", attempt))
+	}
+	if etag := req.Header.Get("If-Match"); etag != "" {
+		attributes = append(attributes, attribute.String("firebase.etag.sent", etag))
+	}
+
+	ctx, span := c.tracer.Start(req.Context(), fmt.Sprintf("%s %s", req.Method, req.URL.Host),
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+	defer span.End()
+
+	httpResp, err := send(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return httpResp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", httpResp.StatusCode))
+	if etag := httpResp.Header.Get("ETag"); etag != "" {
+		span.SetAttributes(attribute.String("firebase.etag.received", etag))
+	}
+	if httpResp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, httpResp.Status)
+	}
+
+	return httpResp, nil
+}
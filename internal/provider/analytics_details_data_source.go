@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AnalyticsDetailsDataSource{}
+
+func NewAnalyticsDetailsDataSource() datasource.DataSource {
+	return &AnalyticsDetailsDataSource{}
+}
+
+// AnalyticsDetailsDataSource defines the data source implementation.
+type AnalyticsDetailsDataSource struct {
+	client *FirebaseClient
+}
+
+// AnalyticsDetailsDataSourceModel describes the data source data model.
+type AnalyticsDetailsDataSourceModel struct {
+	ID                  types.String              `tfsdk:"id"`
+	Project             types.String              `tfsdk:"project"`
+	PropertyID          types.String              `tfsdk:"property_id"`
+	PropertyDisplayName types.String              `tfsdk:"property_display_name"`
+	AccountID           types.String              `tfsdk:"account_id"`
+	StreamMappings      []AnalyticsStreamMapModel `tfsdk:"stream_mappings"`
+}
+
+type AnalyticsStreamMapModel struct {
+	App           types.String `tfsdk:"app"`
+	StreamID      types.String `tfsdk:"stream_id"`
+	MeasurementID types.String `tfsdk:"measurement_id"`
+}
+
+func (d *AnalyticsDetailsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_analytics_details"
+}
+
+func (d *AnalyticsDetailsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Google Analytics property and data streams linked to a Firebase project",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Firebase Project ID",
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID",
+			},
+			"property_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Google Analytics property ID, e.g. `properties/1234`",
+			},
+			"property_display_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Display name of the Google Analytics property",
+			},
+			"account_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Google Analytics account the property belongs to",
+			},
+			"stream_mappings": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data stream of every Firebase app of the project",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"app": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Resource name of the Firebase app",
+						},
+						"stream_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Google Analytics data stream ID",
+						},
+						"measurement_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Measurement ID of web streams, empty for other platforms",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AnalyticsDetailsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_analytics_details", firebaseAPIScopes)
+}
+
+func (d *AnalyticsDetailsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AnalyticsDetailsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	details, err := d.client.GetAnalyticsDetails(ctx, data.Project.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read analytics details of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	data.ID = data.Project
+	data.PropertyID = types.StringValue(details.AnalyticsProperty.ID)
+	data.PropertyDisplayName = types.StringValue(details.AnalyticsProperty.DisplayName)
+	data.AccountID = types.StringValue(details.AnalyticsProperty.AnalyticsAccountID)
+	data.StreamMappings = []AnalyticsStreamMapModel{}
+	for _, m := range details.StreamMappings {
+		data.StreamMappings = append(data.StreamMappings, AnalyticsStreamMapModel{
+			App:           types.StringValue(m.App),
+			StreamID:      types.StringValue(m.StreamID),
+			MeasurementID: types.StringValue(m.MeasurementID),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AnalyticsLinkResource{}
+var _ resource.ResourceWithImportState = &AnalyticsLinkResource{}
+
+func NewAnalyticsLinkResource() resource.Resource {
+	return &AnalyticsLinkResource{}
+}
+
+// AnalyticsLinkResource links a Firebase project to Google Analytics.
+type AnalyticsLinkResource struct {
+	client *FirebaseClient
+}
+
+// AnalyticsLinkResourceModel describes the resource data model.
+type AnalyticsLinkResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Project             types.String `tfsdk:"project"`
+	AnalyticsAccountID  types.String `tfsdk:"analytics_account_id"`
+	AnalyticsPropertyID types.String `tfsdk:"analytics_property_id"`
+}
+
+func (r *AnalyticsLinkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_analytics_link"
+}
+
+func (r *AnalyticsLinkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Links a Firebase project to a Google Analytics property. Destroying the resource unlinks it. Import with the project ID",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Firebase Project ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"analytics_account_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Google Analytics account to provision a new property in. Conflicts with `analytics_property_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("analytics_property_id")),
+				},
+			},
+			"analytics_property_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Existing Google Analytics property to link, e.g. `properties/1234`. Set to the provisioned property when linking with `analytics_account_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AnalyticsLinkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_analytics_link", firebaseAPIScopes)
+}
+
+func (r *AnalyticsLinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AnalyticsLinkResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "link Google Analytics") {
+		return
+	}
+
+	details, err := r.client.AddGoogleAnalytics(ctx, data.Project.ValueString(), data.AnalyticsAccountID.ValueString(), data.AnalyticsPropertyID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to link project %s to Google Analytics: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	data.ID = data.Project
+	data.AnalyticsPropertyID = types.StringValue(details.AnalyticsProperty.ID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AnalyticsLinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AnalyticsLinkResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Project.IsNull() {
+		// This is when we import the state
+		data.Project = data.ID
+	}
+
+	details, err := r.client.GetAnalyticsDetails(ctx, data.Project.ValueString())
+	if errors.Is(err, firebaseclient.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read analytics details of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	data.ID = data.Project
+	data.AnalyticsPropertyID = types.StringValue(details.AnalyticsProperty.ID)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AnalyticsLinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every configurable attribute requires replacement.
+	var data AnalyticsLinkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AnalyticsLinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AnalyticsLinkResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "unlink Google Analytics") {
+		return
+	}
+
+	if err := r.client.RemoveAnalytics(ctx, data.Project.ValueString(), data.AnalyticsPropertyID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unlink project %s from Google Analytics: %s", data.Project.ValueString(), err))
+	}
+}
+
+func (r *AnalyticsLinkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AppCheckDebugTokensDataSource{}
+
+func NewAppCheckDebugTokensDataSource() datasource.DataSource {
+	return &AppCheckDebugTokensDataSource{}
+}
+
+// AppCheckDebugTokensDataSource defines the data source implementation.
+type AppCheckDebugTokensDataSource struct {
+	client *FirebaseClient
+}
+
+// AppCheckDebugTokensDataSourceModel describes the data source data model.
+type AppCheckDebugTokensDataSourceModel struct {
+	ID          types.String              `tfsdk:"id"`
+	Project     types.String              `tfsdk:"project"`
+	AppID       types.String              `tfsdk:"app_id"`
+	DebugTokens []AppCheckDebugTokenModel `tfsdk:"debug_tokens"`
+}
+
+type AppCheckDebugTokenModel struct {
+	Name        types.String `tfsdk:"name"`
+	TokenID     types.String `tfsdk:"token_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	UpdateTime  types.String `tfsdk:"update_time"`
+}
+
+func (d *AppCheckDebugTokensDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_appcheck_debug_tokens"
+}
+
+func (d *AppCheckDebugTokensDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "App Check debug tokens of a Firebase app, to audit the tokens CI runs left behind. Token values are never returned by the API",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project and app ID",
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID or number",
+			},
+			"app_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase app ID, e.g. `1:1234:android:abcd`",
+			},
+			"debug_tokens": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Debug tokens of the app",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Resource name of the debug token",
+						},
+						"token_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the debug token, the last segment of its name",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Display name given to the debug token",
+						},
+						"update_time": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "RFC 3339 time the debug token was last updated",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AppCheckDebugTokensDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_appcheck_debug_tokens", firebaseAPIScopes)
+}
+
+func (d *AppCheckDebugTokensDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppCheckDebugTokensDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokens, err := d.client.ListDebugTokens(ctx, data.Project.ValueString(), data.AppID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list debug tokens of app %s of project %s: %s", data.AppID.ValueString(), data.Project.ValueString(), err))
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), data.AppID.ValueString()))
+	data.DebugTokens = []AppCheckDebugTokenModel{}
+	for _, t := range tokens {
+		updateTime := types.StringNull()
+		if !t.UpdateTime.IsZero() {
+			updateTime = types.StringValue(t.UpdateTime.Format(time.RFC3339))
+		}
+		data.DebugTokens = append(data.DebugTokens, AppCheckDebugTokenModel{
+			Name:        types.StringValue(t.Name),
+			TokenID:     types.StringValue(path.Base(t.Name)),
+			DisplayName: types.StringValue(t.DisplayName),
+			UpdateTime:  updateTime,
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AppDistributionAabInfoDataSource{}
+
+// aabIntegrationHints explains how to fix the integration states other than
+// INTEGRATED.
+var aabIntegrationHints = map[string]string{
+	"PLAY_ACCOUNT_NOT_LINKED":                     "the Firebase project is not linked to a Google Play developer account, link it in the Firebase console under Project settings > Integrations > Google Play",
+	"NO_APP_WITH_GIVEN_BUNDLE_ID_IN_PLAY_ACCOUNT": "the linked Google Play account has no app with the package name of the app, create it in the Play Console",
+	"APP_NOT_PUBLISHED":                           "the app is not published on Google Play yet, upload a first release to any track",
+	"AAB_STATE_UNAVAILABLE":                       "the integration state can't be determined right now, retry later",
+	"PLAY_IAS_TERMS_NOT_ACCEPTED":                 "the Play internal app sharing terms are not accepted, accept them in the Play Console",
+}
+
+func NewAppDistributionAabInfoDataSource() datasource.DataSource {
+	return &AppDistributionAabInfoDataSource{}
+}
+
+// AppDistributionAabInfoDataSource defines the data source implementation.
+type AppDistributionAabInfoDataSource struct {
+	client *FirebaseClient
+}
+
+// AppDistributionAabInfoDataSourceModel describes the data source data model.
+type AppDistributionAabInfoDataSourceModel struct {
+	ID                types.String             `tfsdk:"id"`
+	Project           types.String             `tfsdk:"project"`
+	AppID             types.String             `tfsdk:"app_id"`
+	RequireIntegrated types.Bool               `tfsdk:"require_integrated"`
+	IntegrationState  types.String             `tfsdk:"integration_state"`
+	TestCertificate   *AabTestCertificateModel `tfsdk:"test_certificate"`
+}
+
+type AabTestCertificateModel struct {
+	HashSha1   types.String `tfsdk:"hash_sha1"`
+	HashSha256 types.String `tfsdk:"hash_sha256"`
+	HashMd5    types.String `tfsdk:"hash_md5"`
+}
+
+func (d *AppDistributionAabInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_appdistribution_aab_info"
+}
+
+func (d *AppDistributionAabInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Google Play integration of an Android app for App Distribution of app bundles (AAB), so pipelines can check the Play linkage before distributing an AAB",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project and app ID",
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID or number",
+			},
+			"app_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Android app ID, e.g. `1:1234:android:abcd`",
+			},
+			"require_integrated": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Fail, explaining what is missing, unless the app is `INTEGRATED` with Google Play",
+			},
+			"integration_state": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Google Play integration state, `INTEGRATED` when app bundles can be distributed, otherwise e.g. `PLAY_ACCOUNT_NOT_LINKED`, " +
+					"`NO_APP_WITH_GIVEN_BUNDLE_ID_IN_PLAY_ACCOUNT`, `APP_NOT_PUBLISHED`, `AAB_STATE_UNAVAILABLE` or `PLAY_IAS_TERMS_NOT_ACCEPTED`",
+			},
+			"test_certificate": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Certificate Google Play signs the APKs distributed from app bundles with, e.g. to register with APIs checking the signature. Null until the app is integrated",
+				Attributes: map[string]schema.Attribute{
+					"hash_sha1": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "SHA-1 hash of the certificate",
+					},
+					"hash_sha256": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "SHA-256 hash of the certificate",
+					},
+					"hash_md5": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "MD5 hash of the certificate",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AppDistributionAabInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_appdistribution_aab_info", cloudPlatformAPIScopes)
+}
+
+func (d *AppDistributionAabInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppDistributionAabInfoDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := d.client.GetAabInfo(ctx, data.Project.ValueString(), data.AppID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the app bundle information of app %s of project %s: %s", data.AppID.ValueString(), data.Project.ValueString(), err))
+		return
+	}
+
+	if data.RequireIntegrated.ValueBool() && info.IntegrationState != firebaseclient.AabIntegrated {
+		hint, ok := aabIntegrationHints[info.IntegrationState]
+		if !ok {
+			hint = "check the Google Play integration of the Firebase project"
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("require_integrated"),
+			"App Not Integrated With Google Play",
+			fmt.Sprintf("App bundles of app %s can't be distributed, its integration state is %s: %s.", data.AppID.ValueString(), info.IntegrationState, hint),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), data.AppID.ValueString()))
+	data.IntegrationState = types.StringValue(info.IntegrationState)
+	data.TestCertificate = nil
+	if info.TestCertificate != nil {
+		data.TestCertificate = &AabTestCertificateModel{
+			HashSha1:   types.StringValue(info.TestCertificate.HashSha1),
+			HashSha256: types.StringValue(info.TestCertificate.HashSha256),
+			HashMd5:    types.StringValue(info.TestCertificate.HashMd5),
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
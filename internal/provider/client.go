@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"text/template"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// FirebaseClient is the client handed to resources and data sources. It
+// adds the state the provider keeps for a single Terraform run.
+type FirebaseClient struct {
+	*firebaseclient.Client
+	publishes *publishLog
+
+	// descriptionMarkdown is the policy applied to markdown in descriptions.
+	descriptionMarkdown string
+	// defaultNamespace is the namespace of templates that don't set one, ""
+	// for the firebase namespace.
+	defaultNamespace string
+
+	// defaultLabels are recorded with the labels of every publish.
+	defaultLabels map[string]string
+	// versionDescriptionTemplate renders the version description of every
+	// publish instead of the JSON encoded labels and annotations.
+	versionDescriptionTemplate *template.Template
+
+	// planMetadata reports planned publishes as JSON warnings.
+	planMetadata bool
+	// maxChangedParameters is the max_changed_parameters of resources that
+	// don't set one, 0 for no limit.
+	maxChangedParameters int64
+	// scopes are the OAuth scopes requested, nil when requests are not
+	// authorized with scoped tokens, e.g. in mock mode.
+	scopes []string
+
+	// decrypted holds the plaintexts of the encrypted values decrypted
+	// during the run, by crypto key and ciphertext. They are never written
+	// anywhere.
+	decryptedMu sync.Mutex
+	decrypted   map[string]string
+}
+
+// checkWritable reports an error and returns false when the provider is
+// configured read only.
+func (c *FirebaseClient) checkWritable(diags *diag.Diagnostics, operation string) bool {
+	if !c.ReadOnly() {
+		return true
+	}
+
+	diags.AddError(
+		"Read Only Provider",
+		fmt.Sprintf("Cannot %s: the provider is configured with read_only = true. Plan and refresh work as usual, apply with a provider configuration that allows writes.", operation),
+	)
+	return false
+}
+
+// modifyRemoteConfig is ModifyRemoteConfig for the fine-grained resources.
+// Their publishes to a template are serialized within the run and start
+// from the template and etag the previous one published, instead of all
+// reading the same etag and colliding on the one each publish bumps.
+func (c *FirebaseClient) modifyRemoteConfig(ctx context.Context, project string, modify func(update *firebaseclient.RemoteConfigUpdate) error) (*firebaseclient.RemoteConfigRead, string, error) {
+	latest, etag, unlock := c.publishes.lockTemplate(project)
+	defer unlock()
+
+	target, newEtag, err := c.ModifyRemoteConfigFrom(ctx, project, latest, etag, modify)
+	if err != nil {
+		return target, newEtag, err
+	}
+	c.publishes.recordTemplate(project, target, newEtag)
+
+	return target, newEtag, nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// faultInjectionEnv enables the fault injection transport. It holds a comma
+// separated list of [METHOD=]FAULT:COUNT entries consumed in order, FAULT
+// being an HTTP status code or "etag" for an etag conflict. For example
+// "429:2,PUT=etag:1" fails the first two requests with 429 and the first PUT
+// after them with an etag conflict.
+const faultInjectionEnv = "FIREBASEEXTRA_FAULT_INJECTION"
+
+type injectedFault struct {
+	method    string
+	status    int
+	remaining int
+}
+
+// faultInjectingTransport fails requests with the configured faults before
+// letting them through to the wrapped transport. It exists to exercise
+// error handling in acceptance tests and runbook rehearsals.
+type faultInjectingTransport struct {
+	next http.RoundTripper
+
+	mu     sync.Mutex
+	faults []*injectedFault
+}
+
+// parseFaultInjection parses the value of faultInjectionEnv.
+func parseFaultInjection(spec string) ([]*injectedFault, error) {
+	var faults []*injectedFault
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		fault := &injectedFault{}
+		if method, rest, ok := strings.Cut(entry, "="); ok {
+			fault.method = strings.ToUpper(method)
+			entry = rest
+		}
+
+		code, count, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid fault %q, expected [METHOD=]FAULT:COUNT", entry)
+		}
+
+		if code == "etag" {
+			fault.status = http.StatusConflict
+		} else {
+			status, err := strconv.Atoi(code)
+			if err != nil || status < 400 || status > 599 {
+				return nil, fmt.Errorf("invalid fault %q, expected an HTTP error status or etag", code)
+			}
+			fault.status = status
+		}
+
+		remaining, err := strconv.Atoi(count)
+		if err != nil || remaining < 1 {
+			return nil, fmt.Errorf("invalid fault count %q", count)
+		}
+		fault.remaining = remaining
+
+		faults = append(faults, fault)
+	}
+
+	return faults, nil
+}
+
+func (t *faultInjectingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if status, ok := t.nextFault(req.Method); ok {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return faultResponse(req, status), nil
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// nextFault consumes the next fault matching method.
+func (t *faultInjectingTransport) nextFault(method string) (int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, fault := range t.faults {
+		if fault.remaining == 0 || (fault.method != "" && fault.method != method) {
+			continue
+		}
+		fault.remaining--
+		return fault.status, true
+	}
+
+	return 0, false
+}
+
+// faultResponse mimics a Google API error response.
+func faultResponse(req *http.Request, status int) *http.Response {
+	apiStatus := map[int]string{
+		http.StatusConflict:           "ABORTED",
+		http.StatusTooManyRequests:    "RESOURCE_EXHAUSTED",
+		http.StatusServiceUnavailable: "UNAVAILABLE",
+	}[status]
+	if apiStatus == "" {
+		apiStatus = "INTERNAL"
+	}
+
+	body := fmt.Sprintf(`{"error":{"code":%d,"message":"fault injected by %s","status":%q}}`, status, faultInjectionEnv, apiStatus)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,503 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserImportResource{}
+
+// passwordHashAlgorithms are the hash algorithms accounts:batchCreate
+// imports password hashes of.
+var passwordHashAlgorithms = []string{
+	"HMAC_SHA512", "HMAC_SHA256", "HMAC_SHA1", "HMAC_MD5", "MD5", "SHA512", "SHA256", "SHA1",
+	"PBKDF_SHA1", "PBKDF2_SHA256", "BCRYPT", "SCRYPT", "STANDARD_SCRYPT",
+}
+
+func NewUserImportResource() resource.Resource {
+	return &UserImportResource{}
+}
+
+// UserImportResource imports user accounts into Identity Platform, with the
+// password hashes they have in another environment.
+type UserImportResource struct {
+	client *FirebaseClient
+}
+
+// UserImportResourceModel describes the resource data model.
+type UserImportResourceModel struct {
+	ID       types.String                 `tfsdk:"id"`
+	Project  types.String                 `tfsdk:"project"`
+	TenantID types.String                 `tfsdk:"tenant_id"`
+	Hash     *UserImportHashModel         `tfsdk:"hash"`
+	Users    map[string]ImportedUserModel `tfsdk:"users"`
+}
+
+// UserImportHashModel describes how the imported password hashes were
+// computed.
+type UserImportHashModel struct {
+	Algorithm         types.String `tfsdk:"algorithm"`
+	SignerKey         types.String `tfsdk:"signer_key"`
+	SaltSeparator     types.String `tfsdk:"salt_separator"`
+	KeysVersion       types.Int64  `tfsdk:"keys_version"`
+	Rounds            types.Int64  `tfsdk:"rounds"`
+	MemoryCost        types.Int64  `tfsdk:"memory_cost"`
+	CPUMemCost        types.Int64  `tfsdk:"cpu_mem_cost"`
+	Parallelization   types.Int64  `tfsdk:"parallelization"`
+	BlockSize         types.Int64  `tfsdk:"block_size"`
+	DkLen             types.Int64  `tfsdk:"dk_len"`
+	PasswordHashOrder types.String `tfsdk:"password_hash_order"`
+}
+
+type ImportedUserModel struct {
+	Email            types.String `tfsdk:"email"`
+	EmailVerified    types.Bool   `tfsdk:"email_verified"`
+	DisplayName      types.String `tfsdk:"display_name"`
+	PasswordHash     types.String `tfsdk:"password_hash"`
+	Salt             types.String `tfsdk:"salt"`
+	Disabled         types.Bool   `tfsdk:"disabled"`
+	CustomAttributes types.String `tfsdk:"custom_attributes"`
+}
+
+func (r *UserImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identityplatform_user_import"
+}
+
+func (r *UserImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Imports user accounts into Identity Platform with their password hashes, e.g. to seed test cohorts from another environment. " +
+			"Changed users are imported again over the existing account, removed users and every user on destroy are deleted. " +
+			"Password hashes are sensitive but kept in state like any attribute, keep the state in an encrypted backend. " +
+			"The hash keys are write-only and never stored, which takes Terraform 1.11 or later",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project, and tenant when set",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Google Cloud Project ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Tenant to import the users into, the project itself when omitted",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hash": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "How the password hashes of the users were computed, required when any user has a `password_hash`",
+				Attributes: map[string]schema.Attribute{
+					"algorithm": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Hash algorithm, one of " + strings.Join(passwordHashAlgorithms, ", "),
+						Validators: []validator.String{
+							stringvalidator.OneOf(passwordHashAlgorithms...),
+						},
+					},
+					"signer_key": schema.StringAttribute{
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						MarkdownDescription: "Base64 key of the HMAC and SCRYPT algorithms. Write-only, never stored in state nor compared, change `keys_version` to import the users again with a new key",
+					},
+					"salt_separator": schema.StringAttribute{
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						MarkdownDescription: "Base64 salt separator of the SCRYPT algorithm. Write-only like `signer_key`",
+					},
+					"keys_version": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Version of `signer_key` and `salt_separator`, any change imports every user again with the current keys",
+					},
+					"rounds": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Rounds of the hash, required by most algorithms but the HMAC ones",
+					},
+					"memory_cost": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Memory cost of the SCRYPT algorithm",
+					},
+					"cpu_mem_cost": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "CPU memory cost of the STANDARD_SCRYPT algorithm",
+					},
+					"parallelization": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Parallelization of the STANDARD_SCRYPT algorithm",
+					},
+					"block_size": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Block size of the STANDARD_SCRYPT algorithm",
+					},
+					"dk_len": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Derived key length of the STANDARD_SCRYPT algorithm",
+					},
+					"password_hash_order": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Order salt and password are hashed in, `SALT_AND_PASSWORD` or `PASSWORD_AND_SALT`",
+						Validators: []validator.String{
+							stringvalidator.OneOf("SALT_AND_PASSWORD", "PASSWORD_AND_SALT"),
+						},
+					},
+				},
+			},
+			"users": schema.MapNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Users to import, keyed by local ID",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"email": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Email of the user",
+						},
+						"email_verified": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "Whether the email is verified",
+						},
+						"display_name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Display name of the user",
+						},
+						"password_hash": schema.StringAttribute{
+							Optional:            true,
+							Sensitive:           true,
+							MarkdownDescription: "Base64 password hash, computed as described by `hash`",
+						},
+						"salt": schema.StringAttribute{
+							Optional:            true,
+							Sensitive:           true,
+							MarkdownDescription: "Base64 salt of the password hash",
+						},
+						"disabled": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "Whether the user is disabled",
+						},
+						"custom_attributes": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "JSON custom claims of the user",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *UserImportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_identityplatform_user_import", cloudPlatformAPIScopes)
+}
+
+func (r *UserImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserImportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "import users") {
+		return
+	}
+
+	keys, diags := userImportKeysFromConfig(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = userImportID(&data)
+	data.Users = r.importUsers(ctx, &data, keys, data.Users, false, nil, &resp.Diagnostics)
+
+	// Users that failed to import are left out, the next apply retries them.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserImportResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	localIDs := slices.Sorted(maps.Keys(data.Users))
+	live, err := r.client.LookupUsers(ctx, data.Project.ValueString(), data.TenantID.ValueString(), localIDs)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up the imported users of %s: %s", data.ID.ValueString(), err))
+		return
+	}
+
+	// Password hashes can't be read back, only the profile is refreshed.
+	users := make(map[string]ImportedUserModel, len(live))
+	for _, account := range live {
+		user, ok := data.Users[account.LocalID]
+		if !ok {
+			continue
+		}
+		user.Email = refreshedString(account.Email, user.Email)
+		user.DisplayName = refreshedString(account.DisplayName, user.DisplayName)
+		user.EmailVerified = refreshedBool(account.EmailVerified, user.EmailVerified)
+		user.Disabled = refreshedBool(account.Disabled, user.Disabled)
+		users[account.LocalID] = user
+	}
+	data.Users = users
+	if data.Hash != nil {
+		// States written before the keys were write-only still hold them.
+		data.Hash.SignerKey = types.StringNull()
+		data.Hash.SaltSeparator = types.StringNull()
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state UserImportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "import users") {
+		return
+	}
+	keys, diags := userImportKeysFromConfig(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var removed []string
+	for localID := range state.Users {
+		if _, ok := data.Users[localID]; !ok {
+			removed = append(removed, localID)
+		}
+	}
+	slices.Sort(removed)
+	if len(removed) > 0 {
+		if err := r.client.DeleteUsers(ctx, data.Project.ValueString(), data.TenantID.ValueString(), removed); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete the users removed from %s: %s", data.ID.ValueString(), err))
+			return
+		}
+	}
+
+	// Users are imported again when they or the hash parameters change.
+	changed := make(map[string]ImportedUserModel)
+	for localID, user := range data.Users {
+		if prior, ok := state.Users[localID]; !ok || prior != user || !reflect.DeepEqual(data.Hash, state.Hash) {
+			changed[localID] = user
+		}
+	}
+	data.ID = userImportID(&data)
+	data.Users = r.importUsers(ctx, &data, keys, changed, true, state.Users, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserImportResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "delete the imported users") {
+		return
+	}
+
+	localIDs := slices.Sorted(maps.Keys(data.Users))
+	if err := r.client.DeleteUsers(ctx, data.Project.ValueString(), data.TenantID.ValueString(), localIDs); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete the imported users of %s: %s", data.ID.ValueString(), err))
+	}
+}
+
+// importUsers imports users and returns the users of the model once done:
+// the ones of kept that were not imported and the imported ones. Users the
+// API refuses are reported and left out.
+func (r *UserImportResource) importUsers(ctx context.Context, data *UserImportResourceModel, keys userImportKeys, users map[string]ImportedUserModel, overwrite bool, kept map[string]ImportedUserModel, diags *diag.Diagnostics) map[string]ImportedUserModel {
+	result := make(map[string]ImportedUserModel, len(data.Users))
+	for localID := range data.Users {
+		if user, ok := kept[localID]; ok {
+			result[localID] = user
+		}
+	}
+	if len(users) == 0 {
+		return result
+	}
+
+	request := userImportToAPI(data.Hash)
+	request.SignerKey = keys.signerKey
+	request.SaltSeparator = keys.saltSeparator
+	request.AllowOverwrite = overwrite
+	localIDs := slices.Sorted(maps.Keys(users))
+	for _, localID := range localIDs {
+		user := users[localID]
+		request.Users = append(request.Users, firebaseclient.ImportedUser{
+			LocalID:          localID,
+			Email:            user.Email.ValueString(),
+			EmailVerified:    user.EmailVerified.ValueBool(),
+			DisplayName:      user.DisplayName.ValueString(),
+			PasswordHash:     user.PasswordHash.ValueString(),
+			Salt:             user.Salt.ValueString(),
+			Disabled:         user.Disabled.ValueBool(),
+			CustomAttributes: user.CustomAttributes.ValueString(),
+		})
+	}
+
+	failed, err := r.client.ImportUsers(ctx, data.Project.ValueString(), data.TenantID.ValueString(), request)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to import users into %s: %s", data.ID.ValueString(), err))
+		return result
+	}
+
+	refused := make(map[string]bool, len(failed))
+	var messages []string
+	for _, f := range failed {
+		localID := f.LocalID
+		if localID == "" && f.Index < len(localIDs) {
+			localID = localIDs[f.Index]
+		}
+		refused[localID] = true
+		messages = append(messages, fmt.Sprintf("  %s: %s", localID, f.Message))
+	}
+	if len(messages) > 0 {
+		diags.AddError("Users Not Imported", fmt.Sprintf("Identity Platform refused to import %d users into %s:\n%s", len(messages), data.ID.ValueString(), strings.Join(messages, "\n")))
+	}
+	for localID, user := range users {
+		if !refused[localID] {
+			result[localID] = user
+		}
+	}
+
+	return result
+}
+
+// userImportKeys are the write-only hash keys, only ever in the
+// configuration.
+type userImportKeys struct {
+	signerKey     string
+	saltSeparator string
+}
+
+// userImportKeysFromConfig reads the write-only hash keys, which the plan
+// and state always hold as null.
+func userImportKeysFromConfig(ctx context.Context, config tfsdk.Config) (userImportKeys, diag.Diagnostics) {
+	var hash *UserImportHashModel
+	diags := config.GetAttribute(ctx, path.Root("hash"), &hash)
+	if diags.HasError() || hash == nil {
+		return userImportKeys{}, diags
+	}
+
+	return userImportKeys{
+		signerKey:     hash.SignerKey.ValueString(),
+		saltSeparator: hash.SaltSeparator.ValueString(),
+	}, diags
+}
+
+// userImportToAPI converts the hash parameters into a batchCreate request,
+// without the write-only keys.
+func userImportToAPI(hash *UserImportHashModel) firebaseclient.UserImport {
+	if hash == nil {
+		return firebaseclient.UserImport{}
+	}
+
+	return firebaseclient.UserImport{
+		HashAlgorithm:     hash.Algorithm.ValueString(),
+		Rounds:            hash.Rounds.ValueInt64(),
+		MemoryCost:        hash.MemoryCost.ValueInt64(),
+		CPUMemCost:        hash.CPUMemCost.ValueInt64(),
+		Parallelization:   hash.Parallelization.ValueInt64(),
+		BlockSize:         hash.BlockSize.ValueInt64(),
+		DkLen:             hash.DkLen.ValueInt64(),
+		PasswordHashOrder: hash.PasswordHashOrder.ValueString(),
+	}
+}
+
+func userImportID(data *UserImportResourceModel) types.String {
+	if tenant := data.TenantID.ValueString(); tenant != "" {
+		return types.StringValue(fmt.Sprintf("%s/tenants/%s", data.Project.ValueString(), tenant))
+	}
+
+	return data.Project
+}
+
+// refreshedString returns a live string, null when empty and the prior
+// value is null.
+func refreshedString(live string, prior types.String) types.String {
+	if live == "" && prior.IsNull() {
+		return prior
+	}
+
+	return types.StringValue(live)
+}
+
+// refreshedBool returns a live bool, null when false and the prior value is
+// null.
+func refreshedBool(live bool, prior types.Bool) types.Bool {
+	if !live && prior.IsNull() {
+		return prior
+	}
+
+	return types.BoolValue(live)
+}
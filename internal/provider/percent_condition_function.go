@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the desired interfaces.
+var _ function.Function = &PercentConditionFunction{}
+
+// maxMicroPercent is 100 percent in micro percents.
+const maxMicroPercent = 100_000_000
+
+func NewPercentConditionFunction() function.Function {
+	return &PercentConditionFunction{}
+}
+
+// PercentConditionFunction builds the expression of a condition matching a
+// random percentile range of app instances.
+type PercentConditionFunction struct{}
+
+func (f *PercentConditionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "percent_condition"
+}
+
+func (f *PercentConditionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Remote Config percent condition expression",
+		MarkdownDescription: "Builds the expression of a Remote Config condition matching the app instances whose random percentile, " +
+			"derived from `seed`, lies in the range from `micro_percent_lower` excluded to `micro_percent_upper` included. " +
+			"Micro percents are millionths of a percent, from 0 to 100000000",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "seed",
+				MarkdownDescription: "Seed of the percentile, the same seed and range always match the same instances. Empty for the default seed",
+			},
+			function.Int64Parameter{
+				Name:                "micro_percent_lower",
+				MarkdownDescription: "Lower bound of the range, excluded",
+			},
+			function.Int64Parameter{
+				Name:                "micro_percent_upper",
+				MarkdownDescription: "Upper bound of the range, included",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *PercentConditionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seed string
+	var lower, upper int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &seed, &lower, &upper))
+	if resp.Error != nil {
+		return
+	}
+
+	if strings.ContainsAny(seed, `'"\`) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("seed %q can't contain quotes or backslashes", seed))
+		return
+	}
+	for i, bound := range []int64{lower, upper} {
+		if bound < 0 || bound > maxMicroPercent {
+			resp.Error = function.NewArgumentFuncError(int64(i+1), fmt.Sprintf("%d is out of range, micro percents go from 0 to %d", bound, maxMicroPercent))
+			return
+		}
+	}
+	if lower >= upper {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("upper bound %d must be greater than lower bound %d", upper, lower))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, percentExpression(seed, lower, upper)))
+}
+
+// percentExpression renders a percentile range in the condition grammar,
+// percents with up to six decimals.
+func percentExpression(seed string, lower, upper int64) string {
+	percent := "percent"
+	if seed != "" {
+		percent = fmt.Sprintf("percent('%s')", seed)
+	}
+	format := func(microPercent int64) string {
+		return strconv.FormatFloat(float64(microPercent)/1e6, 'f', -1, 64)
+	}
+
+	return fmt.Sprintf("%s between %s and %s", percent, format(lower), format(upper))
+}
@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProjectResource{}
+var _ resource.ResourceWithImportState = &ProjectResource{}
+
+func NewProjectResource() resource.Resource {
+	return &ProjectResource{}
+}
+
+// ProjectResource manages the display name and annotations of an existing
+// Firebase project.
+type ProjectResource struct {
+	client *FirebaseClient
+}
+
+// ProjectResourceModel describes the resource data model.
+type ProjectResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Project       types.String `tfsdk:"project"`
+	ProjectNumber types.String `tfsdk:"project_number"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Annotations   types.Map    `tfsdk:"annotations"`
+}
+
+func (r *ProjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages the display name and annotations of an existing Firebase project. Destroying the resource leaves the project as is",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Firebase Project ID",
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_number": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Firebase Project number",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "User friendly name of the project. When omitted the display name is not managed",
+			},
+			"annotations": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Annotations of the project, replacing every annotation already set. When omitted the annotations are not managed",
+			},
+		},
+	}
+}
+
+func (r *ProjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_project", firebaseAPIScopes)
+}
+
+func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ProjectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.patch(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ProjectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	importing := data.Project.IsNull()
+	if importing {
+		data.Project = data.ID
+	}
+
+	project, err := r.client.GetProject(ctx, data.Project.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(applyProject(ctx, &data, project, importing)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ProjectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.patch(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Firebase projects are not deleted by this resource, removing it from
+	// the state is enough.
+}
+
+func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// patch updates the managed fields of the project and refreshes the model
+// from the result.
+func (r *ProjectResource) patch(ctx context.Context, data *ProjectResourceModel, diags *diag.Diagnostics) {
+	var patch firebaseclient.FirebaseProject
+	var updateMask []string
+	if !data.DisplayName.IsNull() {
+		patch.DisplayName = data.DisplayName.ValueString()
+		updateMask = append(updateMask, "displayName")
+	}
+	if !data.Annotations.IsNull() {
+		annotations, d := stringMapFromValue(ctx, data.Annotations)
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+		patch.Annotations = annotations
+		updateMask = append(updateMask, "annotations")
+	}
+
+	var project *firebaseclient.FirebaseProject
+	var err error
+	if len(updateMask) == 0 {
+		project, err = r.client.GetProject(ctx, data.Project.ValueString())
+	} else {
+		if !r.client.checkWritable(diags, "update the project") {
+			return
+		}
+		project, err = r.client.PatchProject(ctx, data.Project.ValueString(), patch, updateMask)
+	}
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	diags.Append(applyProject(ctx, data, project, false)...)
+}
+
+// applyProject refreshes the model from a project. Unmanaged attributes stay
+// null unless importing.
+func applyProject(ctx context.Context, data *ProjectResourceModel, project *firebaseclient.FirebaseProject, importing bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.ID = types.StringValue(project.ProjectID)
+	data.Project = types.StringValue(project.ProjectID)
+	data.ProjectNumber = types.StringValue(project.ProjectNumber)
+	if importing || !data.DisplayName.IsNull() {
+		data.DisplayName = types.StringValue(project.DisplayName)
+	}
+	if importing || !data.Annotations.IsNull() {
+		annotations := project.Annotations
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		data.Annotations, diags = types.MapValueFrom(ctx, types.StringType, annotations)
+	}
+
+	return diags
+}
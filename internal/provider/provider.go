@@ -5,16 +5,29 @@ package provider
 
 import (
 	"context"
-	"net"
-	"net/http"
-	"time"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
 )
 
 // Ensure FirebaseExtraProvider satisfies various provider interfaces.
@@ -29,10 +42,61 @@ type FirebaseExtraProvider struct {
 	version string
 }
 
+// Values of scope_preset.
+const (
+	scopePresetCloudPlatform = "cloud-platform"
+	scopePresetFirebase      = "firebase"
+	scopePresetRemoteConfig  = "remoteconfig"
+)
+
+// scopePresets are the OAuth scopes requested for each scope_preset.
+var scopePresets = map[string][]string{
+	scopePresetCloudPlatform: {scopeCloudPlatform},
+	scopePresetFirebase:      {scopeFirebase},
+	scopePresetRemoteConfig:  {scopeRemoteConfig},
+}
+
+// externalAccountCredentials is the type of the credential configs of
+// workload identity federation.
+const externalAccountCredentials = "external_account"
+
+// Environment variables shared with the google provider, so configurations
+// using both providers set them once.
+const (
+	accessTokenEnv               = "GOOGLE_OAUTH_ACCESS_TOKEN"
+	impersonateServiceAccountEnv = "GOOGLE_IMPERSONATE_SERVICE_ACCOUNT"
+	billingProjectEnv            = "GOOGLE_BILLING_PROJECT"
+	userProjectOverrideEnv       = "USER_PROJECT_OVERRIDE"
+)
+
 // FirebaseExtraProviderModel describes the provider data model.
 type FirebaseExtraProviderModel struct {
-	AccessToken types.String `tfsdk:"accesstoken"`
-	Endpoint    types.String `tfsdk:"endpoint"`
+	Credentials       types.String `tfsdk:"credentials"`
+	AccessToken       types.String `tfsdk:"access_token"`
+	LegacyAccessToken types.String `tfsdk:"accesstoken"`
+	Endpoint          types.String `tfsdk:"endpoint"`
+	RequestReason     types.String `tfsdk:"request_reason"`
+	ReadOnly          types.Bool   `tfsdk:"read_only"`
+
+	DescriptionMarkdown types.String `tfsdk:"description_markdown"`
+
+	DefaultNamespace types.String `tfsdk:"default_namespace"`
+	ScopePreset      types.String `tfsdk:"scope_preset"`
+
+	ImpersonateServiceAccount          types.String `tfsdk:"impersonate_service_account"`
+	ImpersonateServiceAccountDelegates types.List   `tfsdk:"impersonate_service_account_delegates"`
+	BillingProject                     types.String `tfsdk:"billing_project"`
+	UserProjectOverride                types.Bool   `tfsdk:"user_project_override"`
+
+	DefaultLabels                     types.Map    `tfsdk:"default_labels"`
+	DefaultVersionDescriptionTemplate types.String `tfsdk:"default_version_description_template"`
+
+	PlanMetadata         types.Bool   `tfsdk:"plan_metadata"`
+	MaxChangedParameters types.Int64  `tfsdk:"max_changed_parameters"`
+	OTLPEndpoint         types.String `tfsdk:"otlp_endpoint"`
+
+	Mock          types.Bool   `tfsdk:"mock"`
+	MockStateFile types.String `tfsdk:"mock_state_file"`
 }
 
 func (p *FirebaseExtraProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -43,15 +107,132 @@ func (p *FirebaseExtraProvider) Metadata(ctx context.Context, req provider.Metad
 func (p *FirebaseExtraProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"credentials": schema.StringAttribute{
+				MarkdownDescription: "Service account key of the provider, either the JSON of the key or the path of a file holding it, e.g. `file(\"key.json\")` or `\"~/keys/firebase.json\"`. " +
+					"Values starting with `{` are read as JSON, others as a path. A workload identity federation credential config (type `external_account`), " +
+					"e.g. from `gcloud iam workload-identity-pools create-cred-config` for AWS or an OIDC provider such as GitHub Actions, authenticates CI systems without Google keys. " +
+					"Without `credentials` nor `access_token` the provider uses Application Default Credentials: " +
+					"`GOOGLE_APPLICATION_CREDENTIALS`, `gcloud auth application-default login` or the service account attached to GCE, GKE or Cloud Build",
+				Sensitive: true,
+				Optional:  true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("accesstoken")),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "OAuth2 access token the requests are authorized with as it is, e.g. from `gcloud auth print-access-token`, instead of a service account key. " +
+					"Tokens are short lived and not refreshed, so they suit runs shorter than their lifetime. Defaults to `" + accessTokenEnv + "` when neither `credentials` nor `accesstoken` is set. " +
+					"Scopes are the ones the token was issued with, `scope_preset` only applies to an impersonated service account",
+				Sensitive: true,
+				Optional:  true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("credentials"), path.MatchRoot("accesstoken")),
+				},
+			},
 			"accesstoken": schema.StringAttribute{
-				MarkdownDescription: "Access Token. Read more on https://firebase.google.com/docs/remote-config/automate-rc#curl. For progrmatically use https://stackoverflow.com/questions/53890526/how-do-i-create-an-access-token-from-service-account-credentials-using-rest-api, or simplest `gcloud auth print-access-token --impersonate-service-account=some-service-account-that-has-firebase-iam-access`",
-				Sensitive:           true,
-				Required:            true,
+				MarkdownDescription: "JSON of the service account key of the provider, despite its name. Deprecated, use `credentials` instead",
+				DeprecationMessage: "accesstoken holds a service account key, not an access token, and is replaced by credentials. " +
+					"Rename the attribute to credentials, which takes the same value and also accepts the path of the key file.",
+				Sensitive: true,
+				Optional:  true,
 			},
 			"endpoint": schema.StringAttribute{
 				MarkdownDescription: "Firebase Endpoint",
 				Optional:            true,
 			},
+			"request_reason": schema.StringAttribute{
+				MarkdownDescription: "Justification sent as the `X-Goog-Request-Reason` header on every Google API request, for organizations using Access Transparency",
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Turn every write into an error, so the configuration can be safely planned and refreshed by observer pipelines with read only credentials",
+				Optional:            true,
+			},
+			"description_markdown": schema.StringAttribute{
+				MarkdownDescription: "What to do with markdown in parameter and group descriptions: `allow` (default) publishes it as is, `reject` fails the plan, `strip` publishes descriptions as plain text",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(descriptionMarkdownPolicies...),
+				},
+			},
+			"default_namespace": schema.StringAttribute{
+				MarkdownDescription: "Namespace of the `firebaseextra_remoteconfig` resources and `firebaseextra_remoteconfig_version` data sources that don't set one, `" + firebaseclient.NamespaceFirebase + "` when omitted",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(firebaseclient.RemoteConfigNamespaces...),
+				},
+			},
+			"scope_preset": schema.StringAttribute{
+				MarkdownDescription: "OAuth scopes requested for the service account of `credentials`: `" + scopePresetCloudPlatform + "` (default), " +
+					"`" + scopePresetFirebase + "` for every Firebase API, or `" + scopePresetRemoteConfig + "` for Remote Config only, " +
+					"with which every other resource and data source fails naming the scope it needs. Lets a minimal permission setup be reviewed from the provider configuration alone",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(scopePresetCloudPlatform, scopePresetFirebase, scopePresetRemoteConfig),
+				},
+			},
+			"impersonate_service_account": schema.StringAttribute{
+				MarkdownDescription: "Email of the service account to impersonate with the principal of `credentials` or `access_token`, which needs `roles/iam.serviceAccountTokenCreator` on it. " +
+					"Defaults to the `" + impersonateServiceAccountEnv + "` environment variable, like the google provider",
+				Optional: true,
+			},
+			"impersonate_service_account_delegates": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Emails of the service accounts in the delegation chain to `impersonate_service_account`, each allowed to impersonate the next",
+				Optional:            true,
+			},
+			"billing_project": schema.StringAttribute{
+				MarkdownDescription: "Project billed and charged the quota of requests when `user_project_override` is set. " +
+					"Defaults to the `" + billingProjectEnv + "` environment variable, like the google provider",
+				Optional: true,
+			},
+			"user_project_override": schema.BoolAttribute{
+				MarkdownDescription: "Bill requests and charge their quota to `billing_project`, or to the project they are about without one, instead of to the project of the credentials. " +
+					"Defaults to the `" + userProjectOverrideEnv + "` environment variable, like the google provider",
+				Optional: true,
+			},
+			"default_labels": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels recorded with the `labels` of every Remote Config publish made through this provider configuration, e.g. one per environment alias. Resource labels win over these",
+				Optional:            true,
+			},
+			"default_version_description_template": schema.StringAttribute{
+				MarkdownDescription: "Go template rendering the version description of every Remote Config publish instead of the JSON encoded labels and annotations, " +
+					"from `.Project`, `.Labels` (including `default_labels`) and `.Annotations`, with `join` and `json` helpers. " +
+					"For example `{{range $k, $v := .Labels}}{{$k}}={{$v}} {{end}}`. Labels and annotations are then not refreshed from the version description",
+				Optional: true,
+			},
+			"plan_metadata": schema.BoolAttribute{
+				MarkdownDescription: "Report every planned Remote Config publish as a `" + planMetadataSummary + "` warning holding one line of JSON with `format_version`, `resource`, `action`, " +
+					"`project`, `namespace`, `changed_parameters` and `template_size_bytes`, so policy engines such as OPA or Sentinel can check publishes without parsing nested diffs. " +
+					"`changed_parameters` is null while the template is not known yet, and `template_size_bytes` is omitted when the published template can't be built at plan time, e.g. with `read_only`",
+				Optional: true,
+			},
+			"max_changed_parameters": schema.Int64Attribute{
+				MarkdownDescription: "Most parameters a publish of a `firebaseextra_remoteconfig` may add, change or remove, for the resources that don't set `max_changed_parameters`. " +
+					"Publishes changing more fail the plan unless the resource sets `allow_large_publish`. No limit when omitted",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"otlp_endpoint": schema.StringAttribute{
+				MarkdownDescription: "URL of an OTLP/HTTP collector to export a trace span of every Firebase API call to, e.g. `http://localhost:4318`, " +
+					"annotated with the project, the attempt of retried publishes, the status and the etags sent and received. " +
+					"The `OTEL_EXPORTER_OTLP_HEADERS` environment variable sets the headers of the export, e.g. for authentication. " +
+					"Spans are exported as they end, so a slow collector slows down applies",
+				Optional: true,
+			},
+			"mock": schema.BoolAttribute{
+				MarkdownDescription: "Serve every API call from an in-memory fake of the Firebase APIs instead of a live project, for `terraform test` runs of modules. " +
+					"Projects start with an empty template at version 1 and publishes compute version numbers and etags like the real API. `credentials` is not needed. " +
+					"The ciphertext of a `default_value_kms` is the base64 encoding of the value",
+				Optional: true,
+			},
+			"mock_state_file": schema.StringAttribute{
+				MarkdownDescription: "File the fake state of `mock` is kept in, so it survives between the plan and apply of a `terraform test` run, each running its own provider process. The state is kept in memory when omitted",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -65,26 +246,166 @@ func (p *FirebaseExtraProvider) Configure(ctx context.Context, req provider.Conf
 		return
 	}
 
-	// Configuration values are now available.
-	// if data.Endpoint.IsNull() { /* ... */ }
-
-	// Example client configuration for data sources and resources
-	client := &http.Client{
-		Timeout: 15 * time.Second,
-		Transport: &http.Transport{
-			Dial: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).Dial,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ResponseHeaderTimeout: 10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-		},
+	httpClient := firebaseclient.DefaultHTTPClient()
+	var tokenSource oauth2.TokenSource
+	var grantedScopes []string
+	if data.Mock.ValueBool() {
+		tflog.Warn(ctx, "serving Firebase API requests from a fake, nothing is sent to Google")
+		httpClient.Transport = firebaseclient.NewFakeTransport(data.MockStateFile.ValueString())
+	} else {
+		preset := scopePresetCloudPlatform
+		if !data.ScopePreset.IsNull() {
+			preset = data.ScopePreset.ValueString()
+		}
+		impersonate := stringFromEnv(data.ImpersonateServiceAccount, impersonateServiceAccountEnv)
+		grantedScopes = scopePresets[preset]
+		scopes := grantedScopes
+		if impersonate != "" {
+			// Generating tokens of another service account takes cloud-platform,
+			// the preset applies to the impersonated one.
+			scopes = scopePresets[scopePresetCloudPlatform]
+		}
+
+		accessToken := data.AccessToken.ValueString()
+		if data.AccessToken.IsNull() && data.Credentials.IsNull() && data.LegacyAccessToken.IsNull() {
+			accessToken = os.Getenv(accessTokenEnv)
+		}
+		// accesstoken is the deprecated name of credentials, errors point at
+		// the attribute set.
+		keyPath, key := path.Root("credentials"), data.Credentials
+		if key.IsNull() && !data.LegacyAccessToken.IsNull() {
+			keyPath, key = path.Root("accesstoken"), data.LegacyAccessToken
+		}
+		switch {
+		case key.IsUnknown():
+			// Only known on apply, resources plan without a client until
+			// then.
+			tflog.Debug(ctx, "credentials are unknown, the provider is configured on apply")
+			return
+		case accessToken != "":
+			// The token is used as it is, with whatever scopes it was issued.
+			tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken, TokenType: "Bearer"})
+			if impersonate == "" {
+				grantedScopes = nil
+			}
+		case key.IsNull():
+			// Application Default Credentials: GOOGLE_APPLICATION_CREDENTIALS,
+			// gcloud auth application-default login or the service account
+			// attached to the GCE, GKE or Cloud Build runtime.
+			defaultCredentials, err := google.FindDefaultCredentials(context.Background(), scopes...)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(keyPath, "Missing Credentials", fmt.Sprintf("Set credentials or access_token, or mock to not call Google APIs. No Application Default Credentials were found either: %s", err))
+				return
+			}
+			tflog.Debug(ctx, "authorize requests with Application Default Credentials")
+			tokenSource = defaultCredentials.TokenSource
+		default:
+			keyJSON, err := credentialsJSON(key.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(keyPath, "Invalid Credentials", fmt.Sprintf("Unable to read credentials: %s", err))
+				return
+			}
+			var credentialsType struct {
+				Type string `json:"type"`
+			}
+			if err := json.Unmarshal(keyJSON, &credentialsType); err != nil {
+				resp.Diagnostics.AddAttributeError(keyPath, "Invalid Credentials", fmt.Sprintf("Unable to parse credentials: %s", err))
+				return
+			}
+			if credentialsType.Type == externalAccountCredentials {
+				// Workload identity federation: the credential config tells
+				// where to read the token of the external identity, e.g. the
+				// AWS metadata or the OIDC token file of the CI job, which is
+				// exchanged for a Google token on every refresh.
+				credentials, err := google.CredentialsFromJSON(context.Background(), keyJSON, scopes...)
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(keyPath, "Invalid Credentials", fmt.Sprintf("Unable to parse external account credentials: %s", err))
+					return
+				}
+				tflog.Debug(ctx, "authorize requests with workload identity federation")
+				tokenSource = credentials.TokenSource
+				break
+			}
+			credentials, err := google.JWTConfigFromJSON(keyJSON, scopes...)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(keyPath, "Invalid Credentials", fmt.Sprintf("Unable to parse service account credentials: %s", err))
+				return
+			}
+			tokenSource = oauth2.ReuseTokenSource(nil, credentials.TokenSource(context.Background()))
+		}
+		if impersonate != "" {
+			var delegates []string
+			if !data.ImpersonateServiceAccountDelegates.IsNull() {
+				resp.Diagnostics.Append(data.ImpersonateServiceAccountDelegates.ElementsAs(ctx, &delegates, false)...)
+			}
+			tokenSource = firebaseclient.ImpersonatedTokenSource(tokenSource, impersonate, delegates, grantedScopes)
+		}
 	}
+
+	userProjectOverride := data.UserProjectOverride.ValueBool()
+	if v := os.Getenv(userProjectOverrideEnv); data.UserProjectOverride.IsNull() && v != "" {
+		override, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("user_project_override"), "Invalid User Project Override", fmt.Sprintf("Unable to parse %s: %s", userProjectOverrideEnv, err))
+			return
+		}
+		userProjectOverride = override
+	}
+
+	if spec := os.Getenv(faultInjectionEnv); spec != "" {
+		faults, err := parseFaultInjection(spec)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Fault Injection", fmt.Sprintf("Unable to parse %s: %s", faultInjectionEnv, err))
+			return
+		}
+		tflog.Warn(ctx, fmt.Sprintf("injecting faults into Firebase API requests: %s", spec))
+		httpClient.Transport = &faultInjectingTransport{next: httpClient.Transport, faults: faults}
+	}
+
+	var tracerProvider trace.TracerProvider
+	if endpoint := data.OTLPEndpoint.ValueString(); endpoint != "" {
+		tp, err := newTracerProvider(ctx, endpoint, p.version)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("otlp_endpoint"), "Invalid OTLP Endpoint", fmt.Sprintf("Unable to export traces to %s: %s", endpoint, err))
+			return
+		}
+		tracerProvider = tp
+	}
+
 	fc := &FirebaseClient{
-		Client:      client,
-		accesstoken: data.AccessToken.ValueString(),
-		endpoint:    data.Endpoint.ValueString(),
+		Client: firebaseclient.New(
+			firebaseclient.WithHTTPClient(httpClient),
+			firebaseclient.WithTokenSource(tokenSource),
+			firebaseclient.WithEndpoint(data.Endpoint.ValueString()),
+			firebaseclient.WithRequestReason(data.RequestReason.ValueString()),
+			firebaseclient.WithReadOnly(data.ReadOnly.ValueBool()),
+			firebaseclient.WithUserProjectOverride(userProjectOverride, stringFromEnv(data.BillingProject, billingProjectEnv)),
+			firebaseclient.WithTracerProvider(tracerProvider),
+		),
+		publishes:            newPublishLog(),
+		descriptionMarkdown:  descriptionMarkdownAllow,
+		planMetadata:         data.PlanMetadata.ValueBool(),
+		maxChangedParameters: data.MaxChangedParameters.ValueInt64(),
+		scopes:               grantedScopes,
+	}
+	if namespace := data.DefaultNamespace.ValueString(); namespace != firebaseclient.NamespaceFirebase {
+		fc.defaultNamespace = namespace
+	}
+	if policy := data.DescriptionMarkdown.ValueString(); policy != "" {
+		fc.descriptionMarkdown = policy
+	}
+	defaultLabels, diags := stringMapFromValue(ctx, data.DefaultLabels)
+	resp.Diagnostics.Append(diags...)
+	fc.defaultLabels = defaultLabels
+	if tmpl := data.DefaultVersionDescriptionTemplate.ValueString(); tmpl != "" {
+		t, err := parseVersionDescriptionTemplate(tmpl)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("default_version_description_template"), "Invalid Template", err.Error())
+		}
+		fc.versionDescriptionTemplate = t
+	}
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	resp.DataSourceData = fc
 	resp.ResourceData = fc
@@ -93,18 +414,35 @@ func (p *FirebaseExtraProvider) Configure(ctx context.Context, req provider.Conf
 func (p *FirebaseExtraProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewRemoteConfigResource,
+		NewProjectResource,
+		NewRemoteConfigRollbackResource,
+		NewAnalyticsLinkResource,
+		NewRemoteConfigParameterResource,
+		NewRemoteConfigScheduleResource,
+		NewRemoteConfigParameterGroupResource,
+		NewRemoteConfigConditionResource,
+		NewUserImportResource,
+		NewRestResource,
 	}
 }
 
 func (p *FirebaseExtraProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		//NewExampleDataSource,
+		NewRemoteConfigMetadataDataSource,
+		NewAnalyticsDetailsDataSource,
+		NewAppCheckDebugTokensDataSource,
+		NewAppDistributionAabInfoDataSource,
+		NewRemoteConfigVersionDataSource,
+		NewRemoteConfigSyncDataSource,
+		NewRemoteConfigExportDataSource,
 	}
 }
 
 func (p *FirebaseExtraProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		//NewExampleFunction,
+		NewPercentConditionFunction,
+		NewVersionCompareFunction,
+		NewValidateConditionFunction,
 	}
 }
 
@@ -115,3 +453,33 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// stringFromEnv returns the value of a string attribute, or of the
+// environment variable env when the attribute is not set.
+func stringFromEnv(value types.String, env string) string {
+	if value.IsNull() {
+		return os.Getenv(env)
+	}
+
+	return value.ValueString()
+}
+
+// credentialsJSON returns the JSON of a service account key or external
+// account credential config given either inline or as the path of its file,
+// "~/" standing for the home directory.
+func credentialsJSON(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "{") {
+		return []byte(value), nil
+	}
+
+	name := value
+	if rest, ok := strings.CutPrefix(name, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		name = filepath.Join(home, rest)
+	}
+
+	return os.ReadFile(name)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCredentialsJSON(t *testing.T) {
+	t.Parallel()
+
+	key := `{"type": "service_account", "project_id": "my-project"}`
+	file := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(file, []byte(key), 0o600); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+
+	tests := map[string]struct {
+		value string
+
+		want    string
+		wantErr bool
+	}{
+		"inline JSON": {
+			value: key,
+			want:  key,
+		},
+		"inline JSON with leading whitespace": {
+			value: "\n  " + key,
+			want:  "\n  " + key,
+		},
+		"path": {
+			value: file,
+			want:  key,
+		},
+		"missing file": {
+			value:   filepath.Join(t.TempDir(), "missing.json"),
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := credentialsJSON(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("credentialsJSON() error = %v, want error %t", err, test.wantErr)
+			}
+			if string(got) != test.want {
+				t.Errorf("credentialsJSON() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCredentialsJSONHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	key := `{"type": "service_account"}`
+	if err := os.WriteFile(filepath.Join(home, "key.json"), []byte(key), 0o600); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+
+	got, err := credentialsJSON("~/key.json")
+	if err != nil {
+		t.Fatalf("credentialsJSON() = %v", err)
+	}
+	if string(got) != key {
+		t.Errorf("credentialsJSON() = %q, want %q", got, key)
+	}
+}
+
+func TestConfigureUnknownCredentials(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	p := New("test")()
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	raw := objectValue(t, schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"credentials": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}, resp)
+	if len(resp.Diagnostics) > 0 {
+		t.Errorf("Configure() diagnostics = %v, want none", resp.Diagnostics)
+	}
+	if resp.ResourceData != nil || resp.DataSourceData != nil {
+		t.Errorf("Configure() configured a client with unknown credentials")
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+)
+
+// publishLog keeps track of the publishes done by the provider during a
+// single Terraform run, so a failing publish can report the blast radius,
+// and of the latest template fine-grained resources published.
+type publishLog struct {
+	mu        sync.Mutex
+	published map[string]string
+	failed    map[string]string
+
+	templateLocks map[string]*sync.Mutex
+	latest        map[string]latestTemplate
+}
+
+// latestTemplate is a template as a publish returned it, live at etag
+// unless something else published since, in which case the publish
+// starting from it fails on the etag and reads the template again.
+type latestTemplate struct {
+	target *firebaseclient.RemoteConfigRead
+	etag   string
+}
+
+func newPublishLog() *publishLog {
+	return &publishLog{
+		published:     make(map[string]string),
+		failed:        make(map[string]string),
+		templateLocks: make(map[string]*sync.Mutex),
+		latest:        make(map[string]latestTemplate),
+	}
+}
+
+// lockTemplate serializes the publishes of fine-grained resources to a
+// template and returns the template and etag the last of them published,
+// nil when there is none. The returned function releases the lock.
+func (l *publishLog) lockTemplate(template string) (*firebaseclient.RemoteConfigRead, string, func()) {
+	l.mu.Lock()
+	lock, ok := l.templateLocks[template]
+	if !ok {
+		lock = &sync.Mutex{}
+		l.templateLocks[template] = lock
+	}
+	l.mu.Unlock()
+
+	lock.Lock()
+	l.mu.Lock()
+	latest := l.latest[template]
+	// Whoever holds the lock may change the template, it is handed over
+	// once.
+	delete(l.latest, template)
+	l.mu.Unlock()
+
+	return latest.target, latest.etag, lock.Unlock
+}
+
+// recordTemplate records the template a publish returned and its etag.
+func (l *publishLog) recordTemplate(template string, target *firebaseclient.RemoteConfigRead, etag string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.latest[template] = latestTemplate{target: target, etag: etag}
+}
+
+// succeeded records a project published at version.
+func (l *publishLog) succeeded(project string, version string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.failed, project)
+	l.published[project] = version
+}
+
+// fail records a project whose publish failed.
+func (l *publishLog) fail(project string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.failed[project] = err.Error()
+}
+
+// summary describes which projects were and were not published so far. The
+// second return value is false when the failing project is the only one
+// touched during the run, in which case the failure itself says it all.
+func (l *publishLog) summary() (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.published)+len(l.failed) < 2 {
+		return "", false
+	}
+
+	var b strings.Builder
+	b.WriteString("This apply published Remote Config templates only partially.")
+
+	b.WriteString("\n\nPublished:")
+	if len(l.published) == 0 {
+		b.WriteString("\n  (none)")
+	}
+	for _, project := range slices.Sorted(maps.Keys(l.published)) {
+		fmt.Fprintf(&b, "\n  - %s (version %s)", project, l.published[project])
+	}
+
+	b.WriteString("\n\nNot published:")
+	for _, project := range slices.Sorted(maps.Keys(l.failed)) {
+		fmt.Fprintf(&b, "\n  - %s: %s", project, l.failed[project])
+	}
+
+	return b.String(), true
+}
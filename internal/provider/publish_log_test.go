@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+)
+
+func TestPublishLogLockTemplate(t *testing.T) {
+	t.Parallel()
+
+	log := newPublishLog()
+	latest, etag, unlock := log.lockTemplate("my-project")
+	if latest != nil || etag != "" {
+		t.Errorf("lockTemplate() = %v, %q, want nothing before a publish", latest, etag)
+	}
+
+	// The next publish waits for the one holding the lock.
+	handedOver := make(chan *firebaseclient.RemoteConfigRead)
+	go func() {
+		latest, _, unlock := log.lockTemplate("my-project")
+		defer unlock()
+		handedOver <- latest
+	}()
+	published := &firebaseclient.RemoteConfigRead{Version: firebaseclient.RemoteConfigVersion{VersionNumber: "2"}}
+	select {
+	case <-handedOver:
+		t.Fatal("lockTemplate() returned while the template is locked")
+	case <-time.After(10 * time.Millisecond):
+	}
+	log.recordTemplate("my-project", published, "etag-2")
+	unlock()
+	if got := <-handedOver; got != published {
+		t.Errorf("lockTemplate() = %v, want the template the previous publish recorded", got)
+	}
+
+	// The template is handed over once, a publish that doesn't record one
+	// leaves the next to read it again.
+	latest, etag, unlock = log.lockTemplate("my-project")
+	defer unlock()
+	if latest != nil || etag != "" {
+		t.Errorf("lockTemplate() = %v, %q, want the template handed over once", latest, etag)
+	}
+
+	// Templates are locked independently.
+	other, _, unlockOther := log.lockTemplate("other-project")
+	unlockOther()
+	if other != nil {
+		t.Errorf("lockTemplate() = %v, want nothing for another template", other)
+	}
+}
+
+func TestModifyRemoteConfigHandover(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	client, transport := newFakeClient()
+	set := func(name, value string) func(update *firebaseclient.RemoteConfigUpdate) error {
+		return func(update *firebaseclient.RemoteConfigUpdate) error {
+			update.Parameters[name] = stringParameter(value)
+			return nil
+		}
+	}
+
+	if _, _, err := client.modifyRemoteConfig(ctx, "my-project", set("first", "1")); err != nil {
+		t.Fatalf("modifyRemoteConfig() = %v", err)
+	}
+	reads := len(transport.sent("GET"))
+	target, _, err := client.modifyRemoteConfig(ctx, "my-project", set("second", "2"))
+	if err != nil {
+		t.Fatalf("modifyRemoteConfig() = %v", err)
+	}
+	if got := len(transport.sent("GET")); got != reads {
+		t.Errorf("modifyRemoteConfig() read the template %d times, want it handed over", got-reads)
+	}
+	if _, ok := target.Parameters["first"]; !ok || target.Version.VersionNumber != "3" {
+		t.Errorf("published version %s with %v, want version 3 keeping first", target.Version.VersionNumber, sortedKeys(target.Parameters))
+	}
+
+	// A template published by someone else in the meantime fails the
+	// handed over etag, the publish starts over from the live template.
+	publishHotfix(t, client, "my-project")
+	target, _, err = client.modifyRemoteConfig(ctx, "my-project", set("third", "3"))
+	if err != nil {
+		t.Fatalf("modifyRemoteConfig() = %v", err)
+	}
+	if got := target.Parameters["welcome"].DefaultValue.Value; got != "hotfix" || target.Version.VersionNumber != "5" {
+		t.Errorf("published version %s with welcome = %q, want version 5 over the hotfix", target.Version.VersionNumber, got)
+	}
+}
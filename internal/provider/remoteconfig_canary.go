@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RemoteConfigCanaryModel describes a canary publish.
+type RemoteConfigCanaryModel struct {
+	Condition types.String `tfsdk:"condition"`
+	Percent   types.Int64  `tfsdk:"percent"`
+	Promote   types.Bool   `tfsdk:"promote"`
+}
+
+// canaryExpression is the expression of the condition generated for a
+// canary cohort.
+func canaryExpression(percent int64) string {
+	return fmt.Sprintf("percent <= %d", percent)
+}
+
+// applyCanary routes the new default values of the payload through the
+// canary condition: the live default keeps being served to everyone but
+// the canary cohort, which gets the new one. Once promoted the canary
+// condition and its conditional values are removed and the new defaults
+// are published as they are.
+func (r *RemoteConfigResource) applyCanary(ctx context.Context, data *RemoteConfigResourceModel, payload firebaseclient.RemoteConfigUpdate, private privateStateGetter) (firebaseclient.RemoteConfigUpdate, error) {
+	if data.Canary == nil {
+		return payload, nil
+	}
+
+	live, err := r.liveTemplate(ctx, data, private)
+	if err != nil {
+		return payload, err
+	}
+
+	condition := data.Canary.Condition.ValueString()
+	promote := data.Canary.Promote.ValueBool()
+	liveParams := firebaseclient.RemoteConfigUpdate{Parameters: live.Parameters, ParameterGroups: live.ParameterGroups}
+
+	canary := func(name string, param firebaseclient.RemoteConfigParameter) firebaseclient.RemoteConfigParameter {
+		liveParam, _, exists := findParameter(&liveParams, name)
+
+		// Conditional values left unmanaged are published as they are
+		// live, but without the canary.
+		if param.ConditionalValues == nil && exists {
+			param.ConditionalValues = maps.Clone(liveParam.ConditionalValues)
+		} else {
+			param.ConditionalValues = maps.Clone(param.ConditionalValues)
+		}
+		delete(param.ConditionalValues, condition)
+
+		if promote || !exists || liveParam.DefaultValue == param.DefaultValue {
+			return param
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("route new default value of %s through canary condition %s", name, condition))
+		if param.ConditionalValues == nil {
+			param.ConditionalValues = map[string]firebaseclient.ConfigValue{}
+		}
+		param.ConditionalValues[condition] = param.DefaultValue
+		param.DefaultValue = liveParam.DefaultValue
+		return param
+	}
+
+	parameters := make(map[string]firebaseclient.RemoteConfigParameter, len(payload.Parameters))
+	for name, param := range payload.Parameters {
+		parameters[name] = canary(name, param)
+	}
+	payload.Parameters = parameters
+
+	groups := make(map[string]firebaseclient.RemoteConfigParameterGroup, len(payload.ParameterGroups))
+	for groupName, group := range payload.ParameterGroups {
+		members := make(map[string]firebaseclient.RemoteConfigParameter, len(group.Parameters))
+		for name, param := range group.Parameters {
+			members[name] = canary(name, param)
+		}
+		group.Parameters = members
+		groups[groupName] = group
+	}
+	payload.ParameterGroups = groups
+
+	conditions := payload.Conditions
+	if conditions == nil {
+		conditions = live.Conditions
+	}
+	payload.Conditions = []firebaseclient.RemoteConfigCondition{}
+	if !promote {
+		// The canary condition is evaluated first so the cohort is exact.
+		payload.Conditions = append(payload.Conditions, firebaseclient.RemoteConfigCondition{
+			Name:       condition,
+			Expression: canaryExpression(data.Canary.Percent.ValueInt64()),
+		})
+	}
+	for _, c := range conditions {
+		if c.Name != condition {
+			payload.Conditions = append(payload.Conditions, c)
+		}
+	}
+
+	return payload, nil
+}
+
+// unfoldCanary reverts in a live template what applyCanary did for a canary
+// that is not promoted yet, so the refreshed model matches the
+// configuration.
+func unfoldCanary(prior *RemoteConfigResourceModel, target *firebaseclient.RemoteConfigRead) {
+	if prior.Canary == nil || prior.Canary.Promote.ValueBool() {
+		return
+	}
+	condition := prior.Canary.Condition.ValueString()
+
+	declared := make(map[string]RemoteConfigParameterModel)
+	forEachParameter(prior, func(_ path.Path, name string, param RemoteConfigParameterModel) {
+		declared[name] = param
+	})
+
+	unfold := func(params map[string]firebaseclient.RemoteConfigParameter) {
+		for name, param := range params {
+			value, ok := param.ConditionalValues[condition]
+			decl, isDeclared := declared[name]
+			if !ok || !isDeclared || parameterToAPI(decl).DefaultValue.Value != value.Value {
+				continue
+			}
+			param.DefaultValue = value
+			param.ConditionalValues = maps.Clone(param.ConditionalValues)
+			delete(param.ConditionalValues, condition)
+			params[name] = param
+		}
+	}
+	unfold(target.Parameters)
+	for _, group := range target.ParameterGroups {
+		unfold(group.Parameters)
+	}
+
+	conditions := []firebaseclient.RemoteConfigCondition{}
+	for _, c := range target.Conditions {
+		if c.Name != condition {
+			conditions = append(conditions, c)
+		}
+	}
+	target.Conditions = conditions
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakePrivateState serves the live template kept by the last refresh.
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+const canaryLiveTemplate = `{
+	"conditions": [{"name": "ios", "expression": "device.os == 'ios'"}],
+	"parameters": {
+		"welcome": {"defaultValue": {"value": "hello"}, "conditionalValues": {"ios": {"value": "hi"}}, "valueType": "STRING"},
+		"limit": {"defaultValue": {"value": "10"}, "valueType": "NUMBER"}
+	},
+	"parameterGroups": {
+		"onboarding": {"parameters": {"steps": {"defaultValue": {"value": "3"}, "valueType": "NUMBER"}}}
+	}
+}`
+
+func canaryModel(promote bool) *RemoteConfigResourceModel {
+	return &RemoteConfigResourceModel{
+		Parameters: map[string]RemoteConfigParameterModel{
+			"welcome": {DefaultValue: types.StringValue("bonjour")},
+			"limit":   {DefaultValue: types.StringValue("10")},
+			"fresh":   {DefaultValue: types.StringValue("new")},
+		},
+		ParameterGroups: map[string]RemoteConfigParameterGroupModel{
+			"onboarding": {Parameters: map[string]RemoteConfigParameterModel{
+				"steps": {DefaultValue: types.StringValue("5")},
+			}},
+		},
+		Canary: &RemoteConfigCanaryModel{
+			Condition: types.StringValue("canary"),
+			Percent:   types.Int64Value(10),
+			Promote:   types.BoolValue(promote),
+		},
+	}
+}
+
+func canaryPayload() firebaseclient.RemoteConfigUpdate {
+	return firebaseclient.RemoteConfigUpdate{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"welcome": stringParameter("bonjour"),
+			"limit":   {DefaultValue: firebaseclient.ConfigValue{Value: "10"}, ValueType: "NUMBER"},
+			"fresh":   stringParameter("new"),
+		},
+		ParameterGroups: map[string]firebaseclient.RemoteConfigParameterGroup{
+			"onboarding": {Parameters: map[string]firebaseclient.RemoteConfigParameter{
+				"steps": {DefaultValue: firebaseclient.ConfigValue{Value: "5"}, ValueType: "NUMBER"},
+			}},
+		},
+	}
+}
+
+func conditionNames(conditions []firebaseclient.RemoteConfigCondition) []string {
+	var names []string
+	for _, c := range conditions {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestApplyCanary(t *testing.T) {
+	t.Parallel()
+
+	r := &RemoteConfigResource{}
+	private := fakePrivateState{liveTemplateKey: []byte(canaryLiveTemplate)}
+	payload, err := r.applyCanary(context.Background(), canaryModel(false), canaryPayload(), private)
+	if err != nil {
+		t.Fatalf("applyCanary() = %v", err)
+	}
+
+	if got := conditionNames(payload.Conditions); !slices.Equal(got, []string{"canary", "ios"}) {
+		t.Errorf("conditions = %v, want canary first", got)
+	}
+	if got := payload.Conditions[0].Expression; got != "percent <= 10" {
+		t.Errorf("canary expression = %q", got)
+	}
+
+	welcome := payload.Parameters["welcome"]
+	if welcome.DefaultValue.Value != "hello" || welcome.ConditionalValues["canary"].Value != "bonjour" {
+		t.Errorf("welcome = %+v, want the live default and the new value under canary", welcome)
+	}
+	// Unmanaged conditional values are carried over from the live template.
+	if welcome.ConditionalValues["ios"].Value != "hi" {
+		t.Errorf("welcome conditional values = %v, want ios kept", welcome.ConditionalValues)
+	}
+	if _, ok := payload.Parameters["limit"].ConditionalValues["canary"]; ok {
+		t.Errorf("limit = %+v, want an unchanged default published as is", payload.Parameters["limit"])
+	}
+	if fresh := payload.Parameters["fresh"]; fresh.DefaultValue.Value != "new" || len(fresh.ConditionalValues) != 0 {
+		t.Errorf("fresh = %+v, want a new parameter published as is", fresh)
+	}
+	steps := payload.ParameterGroups["onboarding"].Parameters["steps"]
+	if steps.DefaultValue.Value != "3" || steps.ConditionalValues["canary"].Value != "5" {
+		t.Errorf("steps = %+v, want the live default and the new value under canary", steps)
+	}
+}
+
+func TestApplyCanaryPromote(t *testing.T) {
+	t.Parallel()
+
+	r := &RemoteConfigResource{}
+	private := fakePrivateState{liveTemplateKey: []byte(canaryLiveTemplate)}
+	payload, err := r.applyCanary(context.Background(), canaryModel(true), canaryPayload(), private)
+	if err != nil {
+		t.Fatalf("applyCanary() = %v", err)
+	}
+
+	if got := conditionNames(payload.Conditions); !slices.Equal(got, []string{"ios"}) {
+		t.Errorf("conditions = %v, want the canary condition removed", got)
+	}
+	welcome := payload.Parameters["welcome"]
+	if welcome.DefaultValue.Value != "bonjour" {
+		t.Errorf("welcome = %+v, want the new default promoted", welcome)
+	}
+	if _, ok := welcome.ConditionalValues["canary"]; ok {
+		t.Errorf("welcome conditional values = %v, want canary removed", welcome.ConditionalValues)
+	}
+}
+
+func TestUnfoldCanary(t *testing.T) {
+	t.Parallel()
+
+	r := &RemoteConfigResource{}
+	prior := canaryModel(false)
+	private := fakePrivateState{liveTemplateKey: []byte(canaryLiveTemplate)}
+	payload, err := r.applyCanary(context.Background(), prior, canaryPayload(), private)
+	if err != nil {
+		t.Fatalf("applyCanary() = %v", err)
+	}
+
+	// Read back what was published, as the next refresh would.
+	target := &firebaseclient.RemoteConfigRead{
+		Conditions:      payload.Conditions,
+		Parameters:      payload.Parameters,
+		ParameterGroups: payload.ParameterGroups,
+	}
+	unfoldCanary(prior, target)
+
+	if got := conditionNames(target.Conditions); !slices.Equal(got, []string{"ios"}) {
+		t.Errorf("conditions = %v, want the canary condition unfolded", got)
+	}
+	for name, want := range map[string]string{"welcome": "bonjour", "limit": "10", "fresh": "new"} {
+		param := target.Parameters[name]
+		if param.DefaultValue.Value != want {
+			t.Errorf("%s default = %q, want %q", name, param.DefaultValue.Value, want)
+		}
+		if _, ok := param.ConditionalValues["canary"]; ok {
+			t.Errorf("%s conditional values = %v, want canary unfolded", name, param.ConditionalValues)
+		}
+	}
+	if got := target.Parameters["welcome"].ConditionalValues["ios"].Value; got != "hi" {
+		t.Errorf("welcome ios = %q, want hi", got)
+	}
+	if got := target.ParameterGroups["onboarding"].Parameters["steps"].DefaultValue.Value; got != "5" {
+		t.Errorf("steps default = %q, want 5", got)
+	}
+}
+
+func TestUnfoldCanaryChangedElsewhere(t *testing.T) {
+	t.Parallel()
+
+	// A canary value that no longer matches the declared default was
+	// changed outside Terraform and shows up as drift.
+	target := &firebaseclient.RemoteConfigRead{
+		Conditions: []firebaseclient.RemoteConfigCondition{{Name: "canary", Expression: "percent <= 10"}},
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"welcome": {
+				DefaultValue:      firebaseclient.ConfigValue{Value: "hello"},
+				ConditionalValues: map[string]firebaseclient.ConfigValue{"canary": {Value: "salut"}},
+			},
+		},
+	}
+	unfoldCanary(canaryModel(false), target)
+
+	if welcome := target.Parameters["welcome"]; welcome.DefaultValue.Value != "hello" || welcome.ConditionalValues["canary"].Value != "salut" {
+		t.Errorf("welcome = %+v, want it left as it is live", welcome)
+	}
+	if len(target.Conditions) != 0 {
+		t.Errorf("conditions = %v, want the canary condition removed", target.Conditions)
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// conditionVariables are the variables conditions can test, by their
+// dotted path. Variables taking a key, e.g. app.userProperty['level'], are
+// listed without it.
+var conditionVariables = []string{
+	"app.audiences",
+	"app.build",
+	"app.customSignal",
+	"app.firebaseInstallationId",
+	"app.firstOpenTimestamp",
+	"app.id",
+	"app.userProperty",
+	"app.version",
+	"dateTime",
+	"device.country",
+	"device.language",
+	"device.os",
+	"percent",
+}
+
+// keyedConditionVariables are the variables indexed by a key.
+var keyedConditionVariables = []string{"app.customSignal", "app.userProperty"}
+
+// conditionComparisons are the binary operators comparing a variable with
+// a value.
+var conditionComparisons = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// conditionToken is a lexical token of a condition expression.
+type conditionToken struct {
+	// kind is one of "ident", "string", "number", "op" and "eof".
+	kind string
+	text string
+	pos  int
+}
+
+// conditionLexer splits a condition expression into tokens.
+func conditionLexer(expr string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			start := i
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("unterminated string starting at offset %d", start)
+				}
+				if runes[i] == '\\' {
+					i++
+					continue
+				}
+				if runes[i] == r {
+					i++
+					break
+				}
+			}
+			tokens = append(tokens, conditionToken{kind: "string", text: string(runes[start:i]), pos: start})
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i++; i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.'); i++ {
+			}
+			tokens = append(tokens, conditionToken{kind: "number", text: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i++; i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_'); i++ {
+			}
+			tokens = append(tokens, conditionToken{kind: "ident", text: string(runes[start:i]), pos: start})
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ",", "."} {
+				if strings.HasPrefix(string(runes[i:]), candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at offset %d", r, i)
+			}
+			tokens = append(tokens, conditionToken{kind: "op", text: op, pos: i})
+			i += len([]rune(op))
+		}
+	}
+
+	return append(tokens, conditionToken{kind: "eof", text: "end of expression", pos: len(runes)}), nil
+}
+
+// conditionParser checks a condition expression against the grammar of
+// Remote Config conditions:
+//
+//	expr       = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" expr ")" | "true" | "false" | test
+//	test       = variable ( comparison value | "in" list | "between" value "and" value
+//	             | "." method "(" [ value { "," value } ] ")" )
+//	variable   = name { "." name } [ "[" string "]" ] [ "(" [ value { "," value } ] ")" ]
+//	value      = string | number | "true" | "false" | list | "(" value ")"
+//	             | name "(" [ value { "," value } ] ")"
+type conditionParser struct {
+	tokens []conditionToken
+	next   int
+}
+
+// parseConditionExpression returns an error describing the first problem
+// of a condition expression, nil when it is valid.
+func parseConditionExpression(expr string) error {
+	tokens, err := conditionLexer(expr)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 1 {
+		return fmt.Errorf("the expression is empty")
+	}
+
+	p := &conditionParser{tokens: tokens}
+	if err := p.expr(); err != nil {
+		return err
+	}
+
+	return p.expect("eof", "")
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.next]
+}
+
+func (p *conditionParser) take() conditionToken {
+	t := p.tokens[p.next]
+	if t.kind != "eof" {
+		p.next++
+	}
+	return t
+}
+
+// accept consumes the next token when it is the given operator or keyword.
+func (p *conditionParser) accept(text string) bool {
+	if t := p.peek(); (t.kind == "op" || t.kind == "ident") && t.text == text {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *conditionParser) expect(kind string, text string) error {
+	t := p.take()
+	if t.kind != kind || (text != "" && t.text != text) {
+		want := text
+		if want == "" {
+			want = kind
+		}
+		if kind == "eof" {
+			want = "end of expression"
+		}
+		return fmt.Errorf("expected %s at offset %d, found %s", want, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *conditionParser) expr() error {
+	if err := p.and(); err != nil {
+		return err
+	}
+	for p.accept("||") {
+		if err := p.and(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *conditionParser) and() error {
+	if err := p.unary(); err != nil {
+		return err
+	}
+	for p.accept("&&") {
+		if err := p.unary(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *conditionParser) unary() error {
+	switch {
+	case p.accept("!"):
+		return p.unary()
+	case p.accept("("):
+		if err := p.expr(); err != nil {
+			return err
+		}
+		return p.expect("op", ")")
+	case p.accept("true"), p.accept("false"):
+		return nil
+	}
+
+	return p.test()
+}
+
+func (p *conditionParser) test() error {
+	if err := p.variable(); err != nil {
+		return err
+	}
+
+	t := p.peek()
+	switch {
+	case t.kind == "op" && slices.Contains(conditionComparisons, t.text):
+		p.next++
+		return p.value()
+	case p.accept("in"):
+		return p.list()
+	case p.accept("between"):
+		if err := p.value(); err != nil {
+			return err
+		}
+		if err := p.expect("ident", "and"); err != nil {
+			return err
+		}
+		return p.value()
+	case p.accept("."):
+		// Methods are named, e.g. contains, or are a comparison of
+		// versions, e.g. app.version.>=(['1.2']).
+		method := p.take()
+		if method.kind != "ident" && !(method.kind == "op" && slices.Contains(conditionComparisons, method.text)) {
+			return fmt.Errorf("expected a method at offset %d, found %s", method.pos, method.text)
+		}
+		return p.arguments()
+	}
+
+	return fmt.Errorf("expected a comparison, in, between or a method at offset %d, found %s", t.pos, t.text)
+}
+
+func (p *conditionParser) variable() error {
+	start := p.take()
+	if start.kind != "ident" {
+		return fmt.Errorf("expected a condition at offset %d, found %s", start.pos, start.text)
+	}
+	name := start.text
+	for p.peek().kind == "op" && p.peek().text == "." && p.next+1 < len(p.tokens) && p.tokens[p.next+1].kind == "ident" &&
+		slices.ContainsFunc(conditionVariables, func(v string) bool { return strings.HasPrefix(v, name+".") }) {
+		p.next++
+		name += "." + p.take().text
+	}
+	if !slices.Contains(conditionVariables, name) {
+		return fmt.Errorf("unknown variable %s at offset %d, expected one of %s", name, start.pos, strings.Join(conditionVariables, ", "))
+	}
+
+	if slices.Contains(keyedConditionVariables, name) {
+		if err := p.expect("op", "["); err != nil {
+			return err
+		}
+		if err := p.expect("string", ""); err != nil {
+			return err
+		}
+		if err := p.expect("op", "]"); err != nil {
+			return err
+		}
+	}
+	if (name == "percent" || name == "dateTime") && p.peek().kind == "op" && p.peek().text == "(" {
+		return p.arguments()
+	}
+
+	return nil
+}
+
+func (p *conditionParser) arguments() error {
+	if err := p.expect("op", "("); err != nil {
+		return err
+	}
+	if p.accept(")") {
+		return nil
+	}
+	for {
+		if err := p.value(); err != nil {
+			return err
+		}
+		if !p.accept(",") {
+			return p.expect("op", ")")
+		}
+	}
+}
+
+func (p *conditionParser) list() error {
+	if err := p.expect("op", "["); err != nil {
+		return err
+	}
+	if p.accept("]") {
+		return nil
+	}
+	for {
+		if err := p.value(); err != nil {
+			return err
+		}
+		if !p.accept(",") {
+			return p.expect("op", "]")
+		}
+	}
+}
+
+func (p *conditionParser) value() error {
+	t := p.peek()
+	switch {
+	case t.kind == "string", t.kind == "number":
+		p.next++
+		return nil
+	case t.kind == "ident" && (t.text == "true" || t.text == "false"):
+		p.next++
+		return nil
+	case t.kind == "op" && t.text == "[":
+		return p.list()
+	case t.kind == "op" && t.text == "(":
+		// Parenthesized values, e.g. app.firstOpenTimestamp > ('2025-01-01T00:00:00').
+		p.next++
+		if err := p.value(); err != nil {
+			return err
+		}
+		return p.expect("op", ")")
+	case t.kind == "ident":
+		// Values built by a function, e.g. dateTime('2025-01-01T00:00:00').
+		p.next++
+		return p.arguments()
+	}
+
+	return fmt.Errorf("expected a value at offset %d, found %s", t.pos, t.text)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConditionExpression(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{
+		"true",
+		"false",
+		"device.os == 'ios'",
+		"device.os != 'android'",
+		"device.country in ['US', 'CA']",
+		"device.language in []",
+		"app.id == '1:1234:ios:abcd'",
+		"app.version.>=(['1.2'])",
+		"app.version.contains(['1.2', '1.3'])",
+		"app.build.<(['42'])",
+		"dateTime < dateTime('2025-01-01T00:00:00', 'UTC')",
+		"dateTime >= dateTime('2025-01-01T00:00:00')",
+		"app.firstOpenTimestamp > ('2025-01-01T00:00:00')",
+		"app.userProperty['level'] in ['gold', 'silver']",
+		"app.userProperty['score'] >= 10",
+		"app.customSignal['plan'] == \"pro\"",
+		"app.audiences.inAtLeastOne(['Purchasers'])",
+		"app.firebaseInstallationId in ['abc']",
+		"percent <= 10",
+		"percent('seed') between 0 and 10.5",
+		"percent between -1 and 20",
+		"!(device.os == 'ios')",
+		"!!true",
+		"device.os == 'ios' && device.country in ['US'] || percent <= 5",
+		"(device.os == 'ios' || device.os == 'android') && app.build.>(['10'])",
+		"device.os == 'it\\'s'",
+	}
+	for _, expr := range valid {
+		if err := parseConditionExpression(expr); err != nil {
+			t.Errorf("parseConditionExpression(%q) = %v, want nil", expr, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"":                                   "the expression is empty",
+		"   ":                                "the expression is empty",
+		"device.os == 'ios":                  "unterminated string",
+		"device.os = 'ios'":                  "unexpected character",
+		"device.os == 'ios' #":               "unexpected character",
+		"device.model == 'pixel'":            "unknown variable device.model",
+		"app == 'x'":                         "unknown variable app",
+		"device.os":                          "expected a comparison",
+		"device.os ==":                       "expected a value",
+		"device.os == 'ios' &&":              "expected a condition",
+		"device.os == 'ios' device.os":       "expected end of expression",
+		"(device.os == 'ios'":                "expected )",
+		"device.country in 'US'":             "expected [",
+		"device.country in ['US' 'CA']":      "expected ]",
+		"percent between 0 10":               "expected and",
+		"app.userProperty == 'x'":            "expected [",
+		"app.userProperty[level] == 'x'":     "expected string",
+		"app.userProperty['level' == 'x'":    "expected ]",
+		"app.version.(['1.2'])":              "expected a method",
+		"app.version.>=('1.2'":               "expected )",
+		"dateTime < dateTime('2025-01-01',)": "expected a value",
+		"== 'ios'":                           "expected a condition",
+	}
+	for expr, want := range invalid {
+		err := parseConditionExpression(expr)
+		if err == nil {
+			t.Errorf("parseConditionExpression(%q) = nil, want an error containing %q", expr, want)
+			continue
+		}
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("parseConditionExpression(%q) = %q, want an error containing %q", expr, err, want)
+		}
+	}
+}
+
+func TestConditionLexer(t *testing.T) {
+	t.Parallel()
+
+	tokens, err := conditionLexer("app.version.>=(['1.2']) && percent <= -5")
+	if err != nil {
+		t.Fatalf("conditionLexer() = %v", err)
+	}
+
+	var got []string
+	for _, token := range tokens {
+		got = append(got, token.kind+":"+token.text)
+	}
+	want := []string{
+		"ident:app", "op:.", "ident:version", "op:.", "op:>=", "op:(", "op:[", "string:'1.2'", "op:]", "op:)",
+		"op:&&", "ident:percent", "op:<=", "number:-5", "eof:end of expression",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("conditionLexer() = %v, want %v", got, want)
+	}
+	if last := tokens[len(tokens)-1]; last.pos != len("app.version.>=(['1.2']) && percent <= -5") {
+		t.Errorf("eof offset = %d", last.pos)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// conditionPlatforms are the values of device.os.
+var conditionPlatforms = []string{"android", "ios"}
+
+// countryCodePattern matches the country codes of device.country.
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// languageTagPattern matches the language tags of device.language.
+var languageTagPattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// conditionLiteralPattern matches the values compiled into single quoted
+// literals of an expression as they are, without quotes nor backslashes,
+// like the seeds of percent_condition.
+var conditionLiteralPattern = regexp.MustCompile(`^[^'"\\]*$`)
+
+// RemoteConfigConditionMatchModel is the structured form of a condition,
+// compiled into its expression.
+type RemoteConfigConditionMatchModel struct {
+	AppID       types.String `tfsdk:"app_id"`
+	Platforms   types.Set    `tfsdk:"platforms"`
+	Languages   types.Set    `tfsdk:"languages"`
+	Countries   types.Set    `tfsdk:"countries"`
+	Percent     types.Int64  `tfsdk:"percent"`
+	PercentSeed types.String `tfsdk:"percent_seed"`
+}
+
+// conditionExpressionAttribute is the expression of a condition, written
+// or compiled from match.
+func conditionExpressionAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Condition expression, see https://firebase.google.com/docs/remote-config/condition-reference. Compiled from `match` when omitted",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("match")),
+		},
+		PlanModifiers: []planmodifier.String{
+			conditionMatchPlanModifier{},
+		},
+	}
+}
+
+// conditionMatchAttribute is the structured alternative to the expression
+// of a condition.
+func conditionMatchAttribute() schema.SingleNestedAttribute {
+	others := func(name string) []path.Expression {
+		var paths []path.Expression
+		for _, other := range []string{"app_id", "platforms", "languages", "countries", "percent"} {
+			if other != name {
+				paths = append(paths, path.MatchRelative().AtParent().AtName(other))
+			}
+		}
+		return paths
+	}
+
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "Structured condition compiled into `expression`, matching the app instances meeting every attribute set, " +
+			"e.g. `{ platforms = [\"ios\"], countries = [\"US\", \"CA\"], percent = 10 }`",
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Firebase app ID of the app, e.g. `1:1234:ios:abcd`",
+				Validators: []validator.String{
+					stringvalidator.AtLeastOneOf(others("app_id")...),
+					stringvalidator.NoneOf(""),
+					stringvalidator.RegexMatches(conditionLiteralPattern, "can't contain quotes or backslashes"),
+				},
+			},
+			"platforms": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Platforms of the device, among " + strings.Join(conditionPlatforms, " and "),
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(conditionPlatforms...)),
+				},
+			},
+			"languages": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Languages of the device, e.g. `en-US`",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(languageTagPattern, "must be a language tag such as en or en-US")),
+				},
+			},
+			"countries": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "ISO 3166-1 alpha-2 codes of the country of the device, e.g. `US`",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(countryCodePattern, "must be an ISO 3166-1 alpha-2 code")),
+				},
+			},
+			"percent": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Percentage of the app instances matched, from 1 to 100",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 100),
+				},
+			},
+			"percent_seed": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Seed of the random percentile of `percent`, so the same instances are matched across conditions using the same seed",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("percent")),
+					stringvalidator.RegexMatches(conditionLiteralPattern, "can't contain quotes or backslashes"),
+				},
+			},
+		},
+	}
+}
+
+// compileConditionMatch renders a structured condition as an expression.
+// It returns false when part of the condition is not known yet.
+func compileConditionMatch(ctx context.Context, match RemoteConfigConditionMatchModel) (string, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var tests []string
+
+	quoted := func(set types.Set) ([]string, bool) {
+		if set.IsUnknown() {
+			return nil, false
+		}
+		var values []string
+		diags.Append(set.ElementsAs(ctx, &values, false)...)
+		slices.Sort(values)
+		for i, v := range values {
+			values[i] = fmt.Sprintf("'%s'", v)
+		}
+		return values, true
+	}
+
+	if match.AppID.IsUnknown() || match.Percent.IsUnknown() || match.PercentSeed.IsUnknown() {
+		return "", false, diags
+	}
+	if !match.AppID.IsNull() {
+		tests = append(tests, fmt.Sprintf("app.id == '%s'", match.AppID.ValueString()))
+	}
+	if !match.Platforms.IsNull() {
+		platforms, known := quoted(match.Platforms)
+		if !known {
+			return "", false, diags
+		}
+		var matches []string
+		for _, p := range platforms {
+			matches = append(matches, "device.os == "+p)
+		}
+		if len(matches) == 1 {
+			tests = append(tests, matches[0])
+		} else {
+			tests = append(tests, "("+strings.Join(matches, " || ")+")")
+		}
+	}
+	for _, set := range []struct {
+		variable string
+		values   types.Set
+	}{{"device.language", match.Languages}, {"device.country", match.Countries}} {
+		if set.values.IsNull() {
+			continue
+		}
+		values, known := quoted(set.values)
+		if !known {
+			return "", false, diags
+		}
+		tests = append(tests, fmt.Sprintf("%s in [%s]", set.variable, strings.Join(values, ", ")))
+	}
+	if !match.Percent.IsNull() {
+		tests = append(tests, percentExpression(match.PercentSeed.ValueString(), 0, match.Percent.ValueInt64()*maxMicroPercent/100))
+	}
+
+	return strings.Join(tests, " && "), true, diags
+}
+
+// conditionMatchPlanModifier plans the expression of a condition compiled
+// from its match attribute, so the expression published shows in the plan.
+type conditionMatchPlanModifier struct{}
+
+func (m conditionMatchPlanModifier) Description(ctx context.Context) string {
+	return "the expression compiled from match when set"
+}
+
+func (m conditionMatchPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m conditionMatchPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var object types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("match"), &object)...)
+	if resp.Diagnostics.HasError() || object.IsNull() {
+		return
+	}
+	if object.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	var match RemoteConfigConditionMatchModel
+	resp.Diagnostics.Append(object.As(ctx, &match, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	expression, known, diags := compileConditionMatch(ctx, match)
+	resp.Diagnostics.Append(diags...)
+	if !known {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	resp.PlanValue = types.StringValue(expression)
+}
@@ -0,0 +1,304 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RemoteConfigConditionResource{}
+var _ resource.ResourceWithImportState = &RemoteConfigConditionResource{}
+
+func NewRemoteConfigConditionResource() resource.Resource {
+	return &RemoteConfigConditionResource{}
+}
+
+// RemoteConfigConditionResource manages a single condition of a Remote
+// Config template, leaving the rest of the template to others.
+type RemoteConfigConditionResource struct {
+	client *FirebaseClient
+}
+
+// RemoteConfigConditionResourceModel describes the resource data model.
+type RemoteConfigConditionResourceModel struct {
+	ID         types.String                     `tfsdk:"id"`
+	Project    types.String                     `tfsdk:"project"`
+	Name       types.String                     `tfsdk:"name"`
+	Expression types.String                     `tfsdk:"expression"`
+	Match      *RemoteConfigConditionMatchModel `tfsdk:"match"`
+	TagColor   types.String                     `tfsdk:"tag_color"`
+	Version    types.String                     `tfsdk:"version"`
+}
+
+func (r *RemoteConfigConditionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remoteconfig_condition"
+}
+
+func (r *RemoteConfigConditionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a single condition of a Remote Config template. New conditions are evaluated after the existing ones. " +
+			"Destroying the resource fails while conditional values still reference the condition",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project and condition name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name referenced by conditional values",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expression": conditionExpressionAttribute(),
+			"match":      conditionMatchAttribute(),
+			"tag_color": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Color the console displays the condition with, one of " + strings.Join(firebaseclient.ConditionTagColors, ", "),
+				Validators: []validator.String{
+					stringvalidator.OneOf(firebaseclient.ConditionTagColors...),
+				},
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Remote Config version the condition was last published or read in",
+			},
+		},
+	}
+}
+
+func (r *RemoteConfigConditionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_remoteconfig_condition", remoteConfigAPIScopes)
+}
+
+func (r *RemoteConfigConditionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RemoteConfigConditionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.publish(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigConditionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RemoteConfigConditionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Project.IsNull() {
+		// This is when we import the state, the ID is project/condition
+		project, name, ok := strings.Cut(data.ID.ValueString(), "/")
+		if !ok {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected <project>/<condition name>, got %q", data.ID.ValueString()))
+			return
+		}
+		data.Project = types.StringValue(project)
+		data.Name = types.StringValue(name)
+	}
+
+	target, _, err := r.client.GetRemoteConfig(ctx, data.Project.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read remote config of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	i := slices.IndexFunc(target.Conditions, func(c firebaseclient.RemoteConfigCondition) bool {
+		return c.Name == data.Name.ValueString()
+	})
+	if i < 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	c := target.Conditions[i]
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), c.Name))
+	data.Expression = types.StringValue(c.Expression)
+	data.TagColor = types.StringNull()
+	if c.TagColor != "" && c.TagColor != "CONDITION_DISPLAY_COLOR_UNSPECIFIED" {
+		data.TagColor = types.StringValue(c.TagColor)
+	}
+	data.Version = types.StringValue(target.Version.VersionNumber)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigConditionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RemoteConfigConditionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.publish(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigConditionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RemoteConfigConditionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "delete the remote config condition") {
+		return
+	}
+
+	name := data.Name.ValueString()
+	_, _, err := r.client.modifyRemoteConfig(ctx, data.Project.ValueString(), func(update *firebaseclient.RemoteConfigUpdate) error {
+		if refs := conditionReferences(update, name); len(refs) > 0 {
+			return fmt.Errorf("condition %s is still referenced by the conditional values of %s", name, strings.Join(refs, ", "))
+		}
+		update.Conditions = slices.DeleteFunc(update.Conditions, func(c firebaseclient.RemoteConfigCondition) bool {
+			return c.Name == name
+		})
+		return nil
+	})
+	if err != nil {
+		addModifyError(&resp.Diagnostics, fmt.Sprintf("delete condition %s of project %s", name, data.Project.ValueString()), err)
+	}
+}
+
+func (r *RemoteConfigConditionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// publish sets the condition in the live template and refreshes the model
+// from the published template.
+func (r *RemoteConfigConditionResource) publish(ctx context.Context, data *RemoteConfigConditionResourceModel, diags *diag.Diagnostics) {
+	if !r.client.checkWritable(diags, "publish the remote config condition") {
+		return
+	}
+
+	condition := firebaseclient.RemoteConfigCondition{
+		Name:       data.Name.ValueString(),
+		Expression: data.Expression.ValueString(),
+		TagColor:   data.TagColor.ValueString(),
+	}
+	target, _, err := r.client.modifyRemoteConfig(ctx, data.Project.ValueString(), func(update *firebaseclient.RemoteConfigUpdate) error {
+		update.Conditions = slices.Clone(update.Conditions)
+		i := slices.IndexFunc(update.Conditions, func(c firebaseclient.RemoteConfigCondition) bool {
+			return c.Name == condition.Name
+		})
+		if i < 0 {
+			update.Conditions = append(update.Conditions, condition)
+		} else {
+			update.Conditions[i] = condition
+		}
+		return nil
+	})
+	if err != nil {
+		addModifyError(diags, fmt.Sprintf("publish condition %s of project %s", condition.Name, data.Project.ValueString()), err)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), condition.Name))
+	data.Version = types.StringValue(target.Version.VersionNumber)
+}
+
+// conditionReferences lists the parameters with a conditional value for a
+// condition.
+func conditionReferences(update *firebaseclient.RemoteConfigUpdate, condition string) []string {
+	var refs []string
+	for name, param := range update.Parameters {
+		if _, ok := param.ConditionalValues[condition]; ok {
+			refs = append(refs, name)
+		}
+	}
+	for _, group := range update.ParameterGroups {
+		for name, param := range group.Parameters {
+			if _, ok := param.ConditionalValues[condition]; ok {
+				refs = append(refs, name)
+			}
+		}
+	}
+	slices.Sort(refs)
+
+	return refs
+}
+
+// addModifyError reports an error of ModifyRemoteConfig made while doing
+// operation, explaining dangling condition references.
+func addModifyError(diags *diag.Diagnostics, operation string, err error) {
+	if errors.Is(err, firebaseclient.ErrUnknownCondition) {
+		diags.AddError(
+			"Unknown Condition",
+			fmt.Sprintf("Unable to %s: %s. Declare the conditions in the template, or in firebaseextra_remoteconfig_condition resources this resource depends on so they are published first.", operation, err),
+		)
+		return
+	}
+
+	diags.AddError("Client Error", fmt.Sprintf("Unable to %s: %s", operation, err))
+}
@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Values of conflict_strategy.
+const (
+	conflictStrategyFail  = "fail"
+	conflictStrategyRetry = "retry"
+)
+
+// maxConflictRetries bounds the publishes retried over a fresh etag.
+const maxConflictRetries = 3
+
+// errManagedFieldsChanged is returned when a publish can't be retried
+// because the template changed where the resource manages it.
+var errManagedFieldsChanged = errors.New("fields managed by the resource changed since the last refresh, refresh and plan again")
+
+// livePrivateState serves a live template as the private state kept by the
+// last refresh.
+type livePrivateState []byte
+
+func (s livePrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	if key != liveTemplateKey {
+		return nil, nil
+	}
+
+	return s, nil
+}
+
+// retryConflict retries a publish rejected because the live template
+// changed since the last refresh, other errors are returned as they are.
+// The template is read again and the publish is retried over the fresh
+// etag as long as only parts the resource doesn't manage changed, anything
+// else fails as the first attempt did.
+func (r *RemoteConfigResource) retryConflict(ctx context.Context, data, prior *RemoteConfigResourceModel, payload firebaseclient.RemoteConfigUpdate, private privateStateGetter, conflict error) (*firebaseclient.RemoteConfigRead, error) {
+	if !errors.Is(conflict, firebaseclient.ErrEtagMismatch) || data.ConflictStrategy.ValueString() != conflictStrategyRetry {
+		return nil, conflict
+	}
+
+	raw, diags := private.GetKey(ctx, liveTemplateKey)
+	if diags.HasError() || len(raw) == 0 {
+		tflog.Debug(ctx, "no live template kept by the last refresh, can't tell what changed")
+		return nil, conflict
+	}
+	var base firebaseclient.RemoteConfigRead
+	if err := json.Unmarshal(raw, &base); err != nil {
+		return nil, conflict
+	}
+	base.Raw = raw
+	planned, err := r.managedTemplateHash(ctx, prior, &base)
+	if err != nil {
+		return nil, conflict
+	}
+
+	for attempt := 1; attempt <= maxConflictRetries; attempt++ {
+		// The publish rejected was the first attempt.
+		ctx := firebaseclient.WithAttempt(ctx, attempt+1)
+		live, etag, err := r.client.GetRemoteConfig(ctx, data.template(), "")
+		if err != nil {
+			return nil, err
+		}
+		if err := r.client.redactLiveValues(ctx, prior, live, true); err != nil {
+			return nil, err
+		}
+		current, err := r.managedTemplateHash(ctx, prior, live)
+		if err != nil {
+			return nil, err
+		}
+		if current != planned {
+			return nil, fmt.Errorf("%w: %w", conflict, errManagedFieldsChanged)
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("only unmanaged fields changed in version %s, retry publish %d of %d", live.Version.VersionNumber, attempt, maxConflictRetries))
+		data.Etag = types.StringValue(etag)
+		published, err := r.preparePublish(ctx, data, prior, payload, livePrivateState(live.Raw))
+		if err != nil {
+			return nil, err
+		}
+		if published, err = r.client.decryptValues(ctx, published, data, prior); err != nil {
+			return nil, err
+		}
+		if published, err = readValueFiles(published, valueFiles(data, prior)); err != nil {
+			return nil, err
+		}
+		target, err := r.writeToFireBase(ctx, published, data)
+		if !errors.Is(err, firebaseclient.ErrEtagMismatch) {
+			return target, err
+		}
+		conflict = err
+	}
+
+	return nil, conflict
+}
+
+// managedTemplateHash hashes the parts of a live template the prior model
+// manages, so two live templates hash the same when they only differ in
+// what the resource leaves to others.
+func (r *RemoteConfigResource) managedTemplateHash(ctx context.Context, prior *RemoteConfigResourceModel, live *firebaseclient.RemoteConfigRead) (string, error) {
+	refreshed := *prior
+	if err := r.refreshTemplate(ctx, &refreshed, live, false); err != nil {
+		return "", err
+	}
+	payload, diags := buildRemoteConfigUpdate(ctx, &refreshed)
+	if diags.HasError() {
+		return "", fmt.Errorf("unable to build the managed template")
+	}
+
+	return templateHash(payload)
+}
+
+// overriddenVersions describes the versions published between the version
+// a forced publish was planned over and the one it published, "" when none
+// was.
+func overriddenVersions(planned, published string) string {
+	from, err := strconv.ParseInt(planned, 10, 64)
+	if err != nil {
+		return ""
+	}
+	to, err := strconv.ParseInt(published, 10, 64)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case to-from <= 1:
+		return ""
+	case to-from == 2:
+		return fmt.Sprintf("version %d", from+1)
+	default:
+		return fmt.Sprintf("versions %d to %d", from+1, to-1)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// conflictModel declares welcome with the given default value, in merge
+// mode so other parameters are left unmanaged.
+func conflictModel(welcome string) *RemoteConfigResourceModel {
+	return &RemoteConfigResourceModel{
+		Project:          types.StringValue("my-project"),
+		ManageMode:       types.StringValue(manageModeMerge),
+		ConflictStrategy: types.StringValue(conflictStrategyRetry),
+		Parameters: map[string]RemoteConfigParameterModel{
+			"welcome": {
+				Name:         types.StringValue("welcome"),
+				ValueType:    types.StringValue("STRING"),
+				DefaultValue: types.StringValue(welcome),
+			},
+		},
+	}
+}
+
+// conflictingPublish refreshes the template the resource last published,
+// lets a console user publish over it and returns the rejected publish of
+// the resource with the private state of the refresh.
+func conflictingPublish(t *testing.T, r *RemoteConfigResource, data *RemoteConfigResourceModel, console firebaseclient.RemoteConfigUpdate) (firebaseclient.RemoteConfigUpdate, fakePrivateState, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	last := firebaseclient.RemoteConfigUpdate{Parameters: map[string]firebaseclient.RemoteConfigParameter{"welcome": stringParameter("hi")}}
+	if _, _, err := r.client.PublishRemoteConfig(ctx, "my-project", "*", last); err != nil {
+		t.Fatalf("PublishRemoteConfig() = %v", err)
+	}
+	refreshed, etag, err := r.client.GetRemoteConfig(ctx, "my-project", "")
+	if err != nil {
+		t.Fatalf("GetRemoteConfig() = %v", err)
+	}
+	if _, _, err := r.client.PublishRemoteConfig(ctx, "my-project", "*", console); err != nil {
+		t.Fatalf("PublishRemoteConfig() = %v", err)
+	}
+
+	payload, diags := buildRemoteConfigUpdate(ctx, data)
+	if diags.HasError() {
+		t.Fatalf("buildRemoteConfigUpdate() diagnostics = %v", diags)
+	}
+	data.Etag = types.StringValue(etag)
+	_, err = r.writeToFireBase(ctx, payload, data)
+	if !errors.Is(err, firebaseclient.ErrEtagMismatch) {
+		t.Fatalf("writeToFireBase() = %v, want an etag mismatch", err)
+	}
+
+	return payload, fakePrivateState{liveTemplateKey: refreshed.Raw}, err
+}
+
+func TestRetryConflictUnmanagedChange(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient()
+	r := &RemoteConfigResource{client: client}
+	data, prior := conflictModel("hello"), conflictModel("hi")
+	payload, private, conflict := conflictingPublish(t, r, data, firebaseclient.RemoteConfigUpdate{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"welcome": stringParameter("hi"),
+			"other":   stringParameter("console"),
+		},
+	})
+
+	target, err := r.retryConflict(context.Background(), data, prior, payload, private, conflict)
+	if err != nil {
+		t.Fatalf("retryConflict() = %v", err)
+	}
+	if target.Version.VersionNumber != "4" {
+		t.Errorf("version = %s, want 4", target.Version.VersionNumber)
+	}
+	if got := target.Parameters["welcome"].DefaultValue.Value; got != "hello" {
+		t.Errorf("welcome = %q, want hello", got)
+	}
+	if got := target.Parameters["other"].DefaultValue.Value; got != "console" {
+		t.Errorf("other = %q, want the unmanaged parameter kept", got)
+	}
+}
+
+func TestRetryConflictManagedChange(t *testing.T) {
+	t.Parallel()
+
+	client, transport := newFakeClient()
+	r := &RemoteConfigResource{client: client}
+	data, prior := conflictModel("hello"), conflictModel("hi")
+	payload, private, conflict := conflictingPublish(t, r, data, firebaseclient.RemoteConfigUpdate{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{"welcome": stringParameter("console")},
+	})
+	publishes := len(transport.sent("PUT"))
+
+	_, err := r.retryConflict(context.Background(), data, prior, payload, private, conflict)
+	if !errors.Is(err, errManagedFieldsChanged) || !errors.Is(err, firebaseclient.ErrEtagMismatch) {
+		t.Fatalf("retryConflict() = %v, want the conflict with managed fields changed", err)
+	}
+	if got := len(transport.sent("PUT")); got != publishes {
+		t.Errorf("retryConflict() published %d times, want no retry", got-publishes)
+	}
+}
+
+func TestRetryConflictFailStrategy(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient()
+	r := &RemoteConfigResource{client: client}
+	data, prior := conflictModel("hello"), conflictModel("hi")
+	data.ConflictStrategy = types.StringValue(conflictStrategyFail)
+	payload, private, conflict := conflictingPublish(t, r, data, firebaseclient.RemoteConfigUpdate{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"welcome": stringParameter("hi"),
+			"other":   stringParameter("console"),
+		},
+	})
+
+	if _, err := r.retryConflict(context.Background(), data, prior, payload, private, conflict); err != conflict {
+		t.Errorf("retryConflict() = %v, want the conflict as it is", err)
+	}
+}
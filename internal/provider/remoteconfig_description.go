@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxParameterDescriptionLength is the longest parameter or parameter group
+// description accepted by the API.
+const maxParameterDescriptionLength = 256
+
+// Policies applied to markdown found in parameter and group descriptions.
+const (
+	descriptionMarkdownAllow  = "allow"
+	descriptionMarkdownReject = "reject"
+	descriptionMarkdownStrip  = "strip"
+)
+
+var descriptionMarkdownPolicies = []string{descriptionMarkdownAllow, descriptionMarkdownReject, descriptionMarkdownStrip}
+
+var (
+	markdownLink     = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	markdownHeading  = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s+`)
+	markdownListItem = regexp.MustCompile(`(?m)^\s*(?:[-*+]|\d+\.)\s+`)
+	markdownEmphasis = regexp.MustCompile("\\*\\*|__|~~|`")
+	markdownSpaces   = regexp.MustCompile(`\s+`)
+)
+
+// stripMarkdown renders a markdown description as plain text on a single
+// line.
+func stripMarkdown(description string) string {
+	s := markdownLink.ReplaceAllString(description, "$1")
+	s = markdownHeading.ReplaceAllString(s, "")
+	s = markdownListItem.ReplaceAllString(s, "")
+	s = markdownEmphasis.ReplaceAllString(s, "")
+	s = markdownSpaces.ReplaceAllString(s, " ")
+
+	return strings.TrimSpace(s)
+}
+
+// hasMarkdown reports whether a description uses markdown syntax.
+func hasMarkdown(description string) bool {
+	return stripMarkdown(description) != strings.Join(strings.Fields(description), " ")
+}
+
+// descriptionValue is the model value of a live parameter or group
+// description. The API omits empty descriptions, so they refresh as null
+// unless the prior value is an explicit empty string.
+func descriptionValue(description string, prior types.String) types.String {
+	if description == "" && (prior.IsNull() || prior.ValueString() != "") {
+		return types.StringNull()
+	}
+
+	return types.StringValue(description)
+}
+
+// checkDescriptionMarkdown reports an error for every description using
+// markdown.
+func checkDescriptionMarkdown(data *RemoteConfigResourceModel, diags *diag.Diagnostics) {
+	report := func(p path.Path, description string) {
+		if hasMarkdown(description) {
+			diags.AddAttributeError(
+				p,
+				"Markdown In Description",
+				fmt.Sprintf("The provider is configured with description_markdown = %q, which forbids markdown in descriptions. Plain text equivalent: %q", descriptionMarkdownReject, stripMarkdown(description)),
+			)
+		}
+	}
+
+	forEachParameter(data, func(p path.Path, _ string, param RemoteConfigParameterModel) {
+		report(p.AtName("description"), param.Description.ValueString())
+	})
+	for name, group := range data.ParameterGroups {
+		report(path.Root("parameter_groups").AtMapKey(name).AtName("description"), group.Description.ValueString())
+	}
+}
+
+// stripPayloadDescriptions returns a copy of the payload with markdown
+// stripped from every parameter and group description.
+func stripPayloadDescriptions(payload firebaseclient.RemoteConfigUpdate) firebaseclient.RemoteConfigUpdate {
+	stripParameters := func(params map[string]firebaseclient.RemoteConfigParameter) map[string]firebaseclient.RemoteConfigParameter {
+		if params == nil {
+			return nil
+		}
+		stripped := make(map[string]firebaseclient.RemoteConfigParameter, len(params))
+		for name, param := range params {
+			param.Description = stripMarkdown(param.Description)
+			stripped[name] = param
+		}
+		return stripped
+	}
+
+	payload.Parameters = stripParameters(payload.Parameters)
+	groups := make(map[string]firebaseclient.RemoteConfigParameterGroup, len(payload.ParameterGroups))
+	for name, group := range payload.ParameterGroups {
+		group.Description = stripMarkdown(group.Description)
+		group.Parameters = stripParameters(group.Parameters)
+		groups[name] = group
+	}
+	payload.ParameterGroups = groups
+
+	return payload
+}
+
+// keepStrippedDescriptions restores the descriptions of the prior model
+// that were published with markdown stripped, so they don't show as drift.
+func keepStrippedDescriptions(prior, data *RemoteConfigResourceModel) {
+	priorParams := make(map[string]RemoteConfigParameterModel)
+	forEachParameter(prior, func(_ path.Path, name string, param RemoteConfigParameterModel) {
+		priorParams[name] = param
+	})
+
+	keep := func(name string, param RemoteConfigParameterModel) RemoteConfigParameterModel {
+		if p, ok := priorParams[name]; ok && stripMarkdown(p.Description.ValueString()) == param.Description.ValueString() {
+			param.Description = p.Description
+		}
+		return param
+	}
+
+	for name, param := range data.Parameters {
+		data.Parameters[name] = keep(name, param)
+	}
+	for groupName, group := range data.ParameterGroups {
+		if p, ok := prior.ParameterGroups[groupName]; ok && stripMarkdown(p.Description.ValueString()) == group.Description.ValueString() {
+			group.Description = p.Description
+		}
+		for name, param := range group.Parameters {
+			group.Parameters[name] = keep(name, param)
+		}
+		data.ParameterGroups[groupName] = group
+	}
+}
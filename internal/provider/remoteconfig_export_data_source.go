@@ -0,0 +1,334 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RemoteConfigExportDataSource{}
+
+func NewRemoteConfigExportDataSource() datasource.DataSource {
+	return &RemoteConfigExportDataSource{}
+}
+
+// RemoteConfigExportDataSource renders a template the way the Firebase CLI
+// writes it.
+type RemoteConfigExportDataSource struct {
+	client *FirebaseClient
+}
+
+// RemoteConfigExportDataSourceModel describes the data source data model.
+type RemoteConfigExportDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Project       types.String `tfsdk:"project"`
+	Namespace     types.String `tfsdk:"namespace"`
+	VersionNumber types.String `tfsdk:"version_number"`
+	Content       types.String `tfsdk:"content"`
+}
+
+func (d *RemoteConfigExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remoteconfig_export"
+}
+
+func (d *RemoteConfigExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Remote Config template exactly as `firebase remoteconfig:get --output` writes it, so review tooling built around the CLI keeps working. " +
+			"Write `content` to a file with `local_file`",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Template and version exported",
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID",
+			},
+			"namespace": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Namespace of the template, defaults to the `default_namespace` of the provider",
+				Validators: []validator.String{
+					stringvalidator.OneOf(firebaseclient.RemoteConfigNamespaces...),
+				},
+			},
+			"version_number": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Version to export, the live one when omitted",
+			},
+			"content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Template in the format of the Firebase CLI: fields in the order of the API, indented by two spaces, without trailing newline",
+			},
+		},
+	}
+}
+
+func (d *RemoteConfigExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_remoteconfig_export", remoteConfigAPIScopes)
+}
+
+func (d *RemoteConfigExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RemoteConfigExportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := data.Namespace.ValueString()
+	if data.Namespace.IsNull() {
+		namespace = d.client.defaultNamespace
+	}
+	template := firebaseclient.TemplateRef(data.Project.ValueString(), namespace)
+	target, _, err := d.client.GetRemoteConfig(ctx, template, data.VersionNumber.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the remote config of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	content, err := cliTemplateJSON(target.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to export the remote config of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", template, target.Version.VersionNumber))
+	data.Content = types.StringValue(content)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// cliTemplateJSON renders a template as the Firebase CLI does with
+// JSON.stringify(template, null, 2): fields in the order of the API, two
+// spaces of indentation and JavaScript's escaping of strings and formatting
+// of numbers.
+func cliTemplateJSON(raw []byte) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var out strings.Builder
+	if err := writeJSValue(dec, &out, ""); err != nil {
+		return "", err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return "", fmt.Errorf("unexpected content after the template")
+	}
+
+	return out.String(), nil
+}
+
+func writeJSValue(dec *json.Decoder, out *strings.Builder, indent string) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		inner := indent + "  "
+		var members []jsMember
+		for dec.More() {
+			var member jsMember
+			if token == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				member.key = key.(string)
+			}
+			var value strings.Builder
+			if err := writeJSValue(dec, &value, inner); err != nil {
+				return err
+			}
+			member.value = value.String()
+			members = append(members, member)
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+
+		closing := "}"
+		if token == '[' {
+			closing = "]"
+		} else {
+			members = jsObjectOrder(members)
+		}
+		out.WriteRune(rune(token))
+		for i, member := range members {
+			if i > 0 {
+				out.WriteString(",")
+			}
+			out.WriteString("\n" + inner)
+			if token == '{' {
+				out.WriteString(jsString(member.key) + ": ")
+			}
+			out.WriteString(member.value)
+		}
+		if len(members) > 0 {
+			out.WriteString("\n" + indent)
+		}
+		out.WriteString(closing)
+	case string:
+		out.WriteString(jsString(token))
+	case json.Number:
+		f, err := token.Float64()
+		if err != nil {
+			return err
+		}
+		out.WriteString(jsNumber(f))
+	case bool:
+		out.WriteString(strconv.FormatBool(token))
+	case nil:
+		out.WriteString("null")
+	}
+
+	return nil
+}
+
+// jsMember is a rendered member of an array or object.
+type jsMember struct {
+	key   string
+	value string
+}
+
+// jsObjectOrder orders the members of an object the way JavaScript
+// enumerates them: array index keys first in ascending order, then the
+// other keys in insertion order. A repeated key keeps its first position
+// and its last value.
+func jsObjectOrder(members []jsMember) []jsMember {
+	position := make(map[string]int, len(members))
+	var indexes, others []jsMember
+	for _, member := range members {
+		if i, ok := position[member.key]; ok {
+			if _, index := jsArrayIndex(member.key); index {
+				indexes[i].value = member.value
+			} else {
+				others[i].value = member.value
+			}
+			continue
+		}
+		if _, index := jsArrayIndex(member.key); index {
+			position[member.key] = len(indexes)
+			indexes = append(indexes, member)
+		} else {
+			position[member.key] = len(others)
+			others = append(others, member)
+		}
+	}
+	slices.SortStableFunc(indexes, func(a, b jsMember) int {
+		i, _ := jsArrayIndex(a.key)
+		j, _ := jsArrayIndex(b.key)
+		return cmp.Compare(i, j)
+	})
+
+	return append(indexes, others...)
+}
+
+// jsArrayIndex reports whether key is an array index in JavaScript, the
+// canonical form of an integer below 2^32 - 1.
+func jsArrayIndex(key string) (uint64, bool) {
+	n, err := strconv.ParseUint(key, 10, 64)
+	if err != nil || n >= math.MaxUint32 || strconv.FormatUint(n, 10) != key {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// jsString quotes s the way JSON.stringify does: only quotes, backslashes
+// and control characters are escaped.
+func jsString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// jsNumber formats f the way JavaScript converts numbers to strings.
+func jsNumber(f float64) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return "null"
+	}
+	if f == 0 {
+		return "0"
+	}
+	if abs := math.Abs(f); abs >= 1e21 || abs < 1e-6 {
+		s := strconv.FormatFloat(f, 'e', -1, 64)
+		mantissa, exponent, _ := strings.Cut(s, "e")
+		if exp, err := strconv.Atoi(exponent); err == nil {
+			if exp > 0 {
+				return fmt.Sprintf("%se+%d", mantissa, exp)
+			}
+			return fmt.Sprintf("%se%d", mantissa, exp)
+		}
+		return s
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
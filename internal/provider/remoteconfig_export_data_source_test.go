@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestCLITemplateJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name, raw, want string
+	}{
+		{"empty object", `{}`, "{}"},
+		{"empty array", `[]`, "[]"},
+		{
+			"nested",
+			`{"parameters":{"b":{"defaultValue":{"value":"x"}},"a":{"valueType":"STRING"}},"conditions":[]}`,
+			"{\n  \"parameters\": {\n    \"b\": {\n      \"defaultValue\": {\n        \"value\": \"x\"\n      }\n    },\n    \"a\": {\n      \"valueType\": \"STRING\"\n    }\n  },\n  \"conditions\": []\n}",
+		},
+		{"integer keys first", `{"b":1,"10":2,"2":3,"a":4}`, "{\n  \"2\": 3,\n  \"10\": 2,\n  \"b\": 1,\n  \"a\": 4\n}"},
+		{"repeated key", `{"a":1,"b":2,"a":3}`, "{\n  \"a\": 3,\n  \"b\": 2\n}"},
+		{"numbers", `[1.0,1e21,0.5,-0]`, "[\n  1,\n  1e+21,\n  0.5,\n  0\n]"},
+		{"literals", `[true,false,null]`, "[\n  true,\n  false,\n  null\n]"},
+		{"escapes", `["<a&b>"," ","tab\t"]`, "[\n  \"<a&b>\",\n  \" \",\n  \"tab\\t\"\n]"},
+	} {
+		got, err := cliTemplateJSON([]byte(tc.raw))
+		if err != nil {
+			t.Errorf("%s: cliTemplateJSON() = %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: cliTemplateJSON() =\n%s\nwant\n%s", tc.name, got, tc.want)
+		}
+	}
+
+	for _, raw := range []string{`{`, `{"a":1} {}`, `{"a":}`} {
+		if _, err := cliTemplateJSON([]byte(raw)); err == nil {
+			t.Errorf("cliTemplateJSON(%q) = nil error, want an error", raw)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// maxListedRemovals bounds the parameters listed when a publish is blocked.
+const maxListedRemovals = 50
+
+// publishedTemplate returns a payload as the template it publishes.
+func publishedTemplate(published firebaseclient.RemoteConfigUpdate) (*firebaseclient.RemoteConfigRead, error) {
+	// Marshal the update so the live fields it preserves are accounted for.
+	jsonData, err := json.Marshal(published)
+	if err != nil {
+		return nil, err
+	}
+	var after firebaseclient.RemoteConfigRead
+	if err := json.Unmarshal(jsonData, &after); err != nil {
+		return nil, err
+	}
+
+	return &after, nil
+}
+
+// removedParameters returns the sorted keys of the live parameters a
+// publish removes, wherever they live in either template.
+func removedParameters(live *firebaseclient.RemoteConfigRead, published firebaseclient.RemoteConfigUpdate) ([]string, error) {
+	after, err := publishedTemplate(published)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := templateParameters(after)
+	var removed []string
+	for _, name := range slices.Sorted(maps.Keys(templateParameters(live))) {
+		if _, ok := kept[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+
+	return removed, nil
+}
+
+// changedLiveParameters returns the sorted keys of the live parameters a
+// publish changes or removes and of the parameters it adds.
+func changedLiveParameters(live *firebaseclient.RemoteConfigRead, published firebaseclient.RemoteConfigUpdate) ([]string, error) {
+	after, err := publishedTemplate(published)
+	if err != nil {
+		return nil, err
+	}
+
+	before, current := templateParameters(live), templateParameters(after)
+	var changed []string
+	for name, param := range current {
+		if prev, ok := before[name]; !ok || !reflect.DeepEqual(prev, param) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := current[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	slices.Sort(changed)
+
+	return changed, nil
+}
+
+// listParameters lists parameter keys one per line, at most
+// maxListedRemovals of them.
+func listParameters(names []string) string {
+	listed := names
+	if len(listed) > maxListedRemovals {
+		listed = listed[:maxListedRemovals]
+	}
+	summary := "  " + strings.Join(listed, "\n  ")
+	if len(names) > len(listed) {
+		summary += fmt.Sprintf("\n  ... and %d more", len(names)-len(listed))
+	}
+
+	return summary
+}
+
+// checkRemovals blocks a publish removing more live parameters than
+// max_parameter_removal allows, unless allow_destructive_publish is set. It
+// reports an error and returns false when the publish must not happen.
+func (r *RemoteConfigResource) checkRemovals(ctx context.Context, data *RemoteConfigResourceModel, published firebaseclient.RemoteConfigUpdate, private privateStateGetter, diags *diag.Diagnostics) bool {
+	if data.MaxParameterRemoval.IsNull() || data.MaxParameterRemoval.IsUnknown() || data.AllowDestructivePublish.ValueBool() {
+		return true
+	}
+
+	live, err := r.liveTemplate(ctx, data, private)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read the live template of project %s to count the parameters removed: %s", data.Project.ValueString(), err))
+		return false
+	}
+	removed, err := removedParameters(live, published)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to count the parameters removed from project %s: %s", data.Project.ValueString(), err))
+		return false
+	}
+
+	limit := data.MaxParameterRemoval.ValueInt64()
+	if int64(len(removed)) <= limit {
+		return true
+	}
+
+	diags.AddError(
+		"Destructive Publish Blocked",
+		fmt.Sprintf("Publishing would remove %d parameters from the live template of project %s at version %s, max_parameter_removal allows %d:\n%s\n\n"+
+			"Check the configuration declares every parameter it should, e.g. after an import, or set allow_destructive_publish = true to publish anyway.",
+			len(removed), data.Project.ValueString(), live.Version.VersionNumber, limit, listParameters(removed)),
+	)
+
+	return false
+}
+
+// maxChangedParameters returns the most parameters a publish of the
+// resource may change, 0 for no limit.
+func (r *RemoteConfigResource) maxChangedParameters(data *RemoteConfigResourceModel) int64 {
+	if !data.MaxChangedParameters.IsNull() {
+		return data.MaxChangedParameters.ValueInt64()
+	}
+
+	return r.client.maxChangedParameters
+}
+
+// checkChanges blocks a publish adding, changing or removing more
+// parameters than max_changed_parameters allows, unless allow_large_publish
+// is set, in which case it only warns. It reports an error and returns false
+// when the publish must not happen.
+func (r *RemoteConfigResource) checkChanges(ctx context.Context, data *RemoteConfigResourceModel, published firebaseclient.RemoteConfigUpdate, private privateStateGetter, diags *diag.Diagnostics) bool {
+	limit := r.maxChangedParameters(data)
+	if limit == 0 || data.MaxChangedParameters.IsUnknown() {
+		return true
+	}
+
+	live, err := r.liveTemplate(ctx, data, private)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read the live template of project %s to count the parameters changed: %s", data.Project.ValueString(), err))
+		return false
+	}
+	changed, err := changedLiveParameters(live, published)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to count the parameters changed in project %s: %s", data.Project.ValueString(), err))
+		return false
+	}
+	if int64(len(changed)) <= limit {
+		return true
+	}
+
+	if data.AllowLargePublish.ValueBool() {
+		diags.AddWarning(
+			"Large Publish",
+			fmt.Sprintf("Publishing changes %d parameters of the live template of project %s at version %s, more than the %d max_changed_parameters allows, "+
+				"published anyway as allow_large_publish is set:\n%s", len(changed), data.Project.ValueString(), live.Version.VersionNumber, limit, listParameters(changed)),
+		)
+		return true
+	}
+	diags.AddError(
+		"Large Publish Blocked",
+		fmt.Sprintf("Publishing would add, change or remove %d parameters of the live template of project %s at version %s, max_changed_parameters allows %d:\n%s\n\n"+
+			"Check the configuration reads the intended template, e.g. the right template file for the environment, or set allow_large_publish = true to publish anyway.",
+			len(changed), data.Project.ValueString(), live.Version.VersionNumber, limit, listParameters(changed)),
+	)
+
+	return false
+}
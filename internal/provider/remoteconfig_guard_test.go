@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const guardLiveTemplate = `{
+	"version": {"versionNumber": "4"},
+	"parameters": {
+		"welcome": {"defaultValue": {"value": "hello"}, "valueType": "STRING"},
+		"limit": {"defaultValue": {"value": "10"}, "valueType": "NUMBER"}
+	}
+}`
+
+// guardPayload keeps welcome, changes limit and adds n parameters.
+func guardPayload(added int) firebaseclient.RemoteConfigUpdate {
+	payload := firebaseclient.RemoteConfigUpdate{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"welcome": stringParameter("hello"),
+			"limit":   {DefaultValue: firebaseclient.ConfigValue{Value: "20"}, ValueType: "NUMBER"},
+		},
+	}
+	for i := range added {
+		payload.Parameters[fmt.Sprintf("added_%d", i)] = stringParameter("new")
+	}
+	return payload
+}
+
+func TestChangedLiveParameters(t *testing.T) {
+	t.Parallel()
+
+	live := &firebaseclient.RemoteConfigRead{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"welcome": stringParameter("hello"),
+			"limit":   stringParameter("10"),
+			"removed": stringParameter("gone"),
+		},
+		ParameterGroups: map[string]firebaseclient.RemoteConfigParameterGroup{
+			"onboarding": {Parameters: map[string]firebaseclient.RemoteConfigParameter{"moved": stringParameter("1")}},
+		},
+	}
+	published := firebaseclient.RemoteConfigUpdate{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"welcome": stringParameter("hello"),
+			"limit":   stringParameter("20"),
+			"added":   stringParameter("new"),
+			// Moving a parameter out of its group doesn't change it.
+			"moved": stringParameter("1"),
+		},
+	}
+
+	changed, err := changedLiveParameters(live, published)
+	if err != nil {
+		t.Fatalf("changedLiveParameters() = %v", err)
+	}
+	if want := []string{"added", "limit", "removed"}; !slices.Equal(changed, want) {
+		t.Errorf("changedLiveParameters() = %v, want %v", changed, want)
+	}
+}
+
+func TestCheckChanges(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		providerLimit int64
+		limit         types.Int64
+		allow         bool
+		added         int
+
+		wantPublish bool
+		wantSummary string
+	}{
+		"no limit": {
+			limit: types.Int64Null(), added: 100,
+			wantPublish: true,
+		},
+		"under the limit": {
+			limit: types.Int64Value(3), added: 1,
+			wantPublish: true,
+		},
+		"at the limit": {
+			limit: types.Int64Value(3), added: 2,
+			wantPublish: true,
+		},
+		"over the limit": {
+			limit: types.Int64Value(3), added: 3,
+			wantSummary: "Large Publish Blocked",
+		},
+		"over the limit allowed": {
+			limit: types.Int64Value(3), allow: true, added: 3,
+			wantPublish: true, wantSummary: "Large Publish",
+		},
+		"unknown limit": {
+			limit: types.Int64Unknown(), providerLimit: 1, added: 3,
+			wantPublish: true,
+		},
+		"provider default": {
+			providerLimit: 2, limit: types.Int64Null(), added: 2,
+			wantSummary: "Large Publish Blocked",
+		},
+		"provider default allowed": {
+			providerLimit: 2, limit: types.Int64Null(), allow: true, added: 2,
+			wantPublish: true, wantSummary: "Large Publish",
+		},
+		"resource overrides provider default": {
+			providerLimit: 2, limit: types.Int64Value(10), added: 5,
+			wantPublish: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &RemoteConfigResource{client: &FirebaseClient{maxChangedParameters: test.providerLimit}}
+			data := &RemoteConfigResourceModel{
+				Project:              types.StringValue("my-project"),
+				MaxChangedParameters: test.limit,
+				AllowLargePublish:    types.BoolValue(test.allow),
+			}
+			private := fakePrivateState{liveTemplateKey: []byte(guardLiveTemplate)}
+
+			var diags diag.Diagnostics
+			publish := r.checkChanges(context.Background(), data, guardPayload(test.added), private, &diags)
+
+			if publish != test.wantPublish {
+				t.Errorf("checkChanges() = %t, want %t", publish, test.wantPublish)
+			}
+			var summaries []string
+			for _, d := range diags {
+				summaries = append(summaries, d.Summary())
+			}
+			switch {
+			case test.wantSummary == "" && len(diags) > 0:
+				t.Errorf("diagnostics = %v, want none", summaries)
+			case test.wantSummary != "" && (len(diags) != 1 || diags[0].Summary() != test.wantSummary):
+				t.Errorf("diagnostics = %v, want %s", summaries, test.wantSummary)
+			}
+		})
+	}
+}
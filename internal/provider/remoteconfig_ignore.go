@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ignoredParameters matches the parameters a model excludes from
+// management, by key or by key prefix.
+type ignoredParameters struct {
+	keys     map[string]bool
+	prefixes []string
+}
+
+// ignores reports whether the parameter with the given key is excluded.
+func (p ignoredParameters) ignores(name string) bool {
+	if p.keys[name] {
+		return true
+	}
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// empty reports whether no parameter is excluded.
+func (p ignoredParameters) empty() bool {
+	return len(p.keys) == 0 && len(p.prefixes) == 0
+}
+
+// ignoredKeys returns the parameters and parameter groups the model
+// excludes from management.
+func ignoredKeys(ctx context.Context, data *RemoteConfigResourceModel) (params ignoredParameters, groups map[string]bool) {
+	toSlice := func(v types.Set) []string {
+		var keys []string
+		if !v.IsNull() && !v.IsUnknown() {
+			v.ElementsAs(ctx, &keys, false)
+		}
+		return keys
+	}
+	toSet := func(v types.Set) map[string]bool {
+		keys := toSlice(v)
+		set := make(map[string]bool, len(keys))
+		for _, k := range keys {
+			set[k] = true
+		}
+		return set
+	}
+
+	params = ignoredParameters{keys: toSet(data.IgnoreParameters), prefixes: toSlice(data.UnmanagedKeyPrefixes)}
+	return params, toSet(data.IgnoreParameterGroups)
+}
+
+// dropIgnored removes the ignored parameters and groups from a refreshed
+// model. Groups the prior model doesn't declare and that only hold ignored
+// parameters are dropped too, they are only published to keep those
+// parameters in place.
+func dropIgnored(ctx context.Context, prior, data *RemoteConfigResourceModel) {
+	params, groups := ignoredKeys(ctx, prior)
+	if params.empty() && len(groups) == 0 {
+		return
+	}
+
+	for name := range data.Parameters {
+		if params.ignores(name) {
+			delete(data.Parameters, name)
+		}
+	}
+
+	for name, group := range data.ParameterGroups {
+		if groups[name] {
+			delete(data.ParameterGroups, name)
+			continue
+		}
+
+		hadIgnored := false
+		for paramName := range group.Parameters {
+			if params.ignores(paramName) {
+				delete(group.Parameters, paramName)
+				hadIgnored = true
+			}
+		}
+		if _, declared := prior.ParameterGroups[name]; hadIgnored && len(group.Parameters) == 0 && !declared {
+			delete(data.ParameterGroups, name)
+		}
+	}
+}
+
+// keepIgnored copies the ignored parameters and groups of the live template
+// into the payload so publishing doesn't remove them.
+func (r *RemoteConfigResource) keepIgnored(ctx context.Context, data *RemoteConfigResourceModel, payload firebaseclient.RemoteConfigUpdate, private privateStateGetter) (firebaseclient.RemoteConfigUpdate, error) {
+	params, groups := ignoredKeys(ctx, data)
+	if params.empty() && len(groups) == 0 {
+		return payload, nil
+	}
+
+	live, err := r.liveTemplate(ctx, data, private)
+	if err != nil {
+		return payload, err
+	}
+
+	// Copy the maps, the payload may share them with the declared template.
+	payload.Parameters = maps.Clone(payload.Parameters)
+	payload.ParameterGroups = maps.Clone(payload.ParameterGroups)
+	if payload.Parameters == nil {
+		payload.Parameters = map[string]firebaseclient.RemoteConfigParameter{}
+	}
+	if payload.ParameterGroups == nil {
+		payload.ParameterGroups = map[string]firebaseclient.RemoteConfigParameterGroup{}
+	}
+
+	for name, param := range live.Parameters {
+		if params.ignores(name) {
+			payload.Parameters[name] = param
+		}
+	}
+	for name, group := range live.ParameterGroups {
+		if groups[name] {
+			payload.ParameterGroups[name] = group
+			continue
+		}
+
+		for paramName, param := range group.Parameters {
+			if !params.ignores(paramName) {
+				continue
+			}
+			target, ok := payload.ParameterGroups[name]
+			if !ok {
+				target = firebaseclient.RemoteConfigParameterGroup{Description: group.Description}
+			}
+			target.Parameters = maps.Clone(target.Parameters)
+			if target.Parameters == nil {
+				target.Parameters = map[string]firebaseclient.RemoteConfigParameter{}
+			}
+			target.Parameters[paramName] = param
+			payload.ParameterGroups[name] = target
+		}
+	}
+
+	return payload, nil
+}
+
+// ignoredKeysValidator checks that ignored or unmanaged parameters and
+// ignored groups are not declared at the same time.
+type ignoredKeysValidator struct{}
+
+func (v ignoredKeysValidator) Description(ctx context.Context) string {
+	return "ignored parameters and parameter groups must not be declared"
+}
+
+func (v ignoredKeysValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ignoredKeysValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	data, ok := getValidatableConfig(ctx, req)
+	if !ok {
+		return
+	}
+
+	params, groups := ignoredKeys(ctx, data)
+	forEachParameter(data, func(p path.Path, name string, _ RemoteConfigParameterModel) {
+		switch {
+		case params.keys[name]:
+			resp.Diagnostics.AddAttributeError(
+				p,
+				"Ignored Parameter Declared",
+				fmt.Sprintf("Parameter %q is listed in ignore_parameters and can't be declared.", name),
+			)
+		case params.ignores(name):
+			resp.Diagnostics.AddAttributeError(
+				p,
+				"Unmanaged Parameter Declared",
+				fmt.Sprintf("Parameter %q starts with one of unmanaged_key_prefixes and can't be declared.", name),
+			)
+		}
+	})
+	for name := range data.ParameterGroups {
+		if groups[name] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("parameter_groups").AtMapKey(name),
+				"Ignored Parameter Group Declared",
+				fmt.Sprintf("Parameter group %q is listed in ignore_parameter_groups and can't be declared.", name),
+			)
+		}
+	}
+}
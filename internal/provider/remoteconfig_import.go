@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// projectIDPattern matches project IDs, domain scoped ones included.
+var projectIDPattern = regexp.MustCompile(`^([a-z0-9.-]+:)?[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+
+const remoteConfigImportFormats = "<project>, projects/<project>/namespaces/<namespace> or <project>:<namespace>"
+
+// parseRemoteConfigImportID splits the import ID of a template into its
+// project and namespace, "" for the firebase namespace. A colon only
+// separates a namespace when a known namespace follows it, domain scoped
+// project IDs contain one too.
+func parseRemoteConfigImportID(id string) (string, string, error) {
+	project, namespace := id, ""
+	if rest, ok := strings.CutPrefix(id, "projects/"); ok {
+		var found bool
+		project, namespace, found = strings.Cut(rest, "/namespaces/")
+		if !found || namespace == "" {
+			return "", "", fmt.Errorf("expected %s, got %q", remoteConfigImportFormats, id)
+		}
+	} else if i := strings.LastIndex(id, ":"); i >= 0 && slices.Contains(firebaseclient.RemoteConfigNamespaces, id[i+1:]) {
+		project, namespace = id[:i], id[i+1:]
+	}
+
+	if !projectIDPattern.MatchString(project) {
+		return "", "", fmt.Errorf("%q is not a valid project ID, expected %s", project, remoteConfigImportFormats)
+	}
+	if namespace != "" && !slices.Contains(firebaseclient.RemoteConfigNamespaces, namespace) {
+		return "", "", fmt.Errorf("unknown namespace %q, expected one of %s", namespace, strings.Join(firebaseclient.RemoteConfigNamespaces, ", "))
+	}
+	if namespace == firebaseclient.NamespaceFirebase {
+		namespace = ""
+	}
+
+	return project, namespace, nil
+}
+
+// template returns the reference of the template the model manages.
+func (m *RemoteConfigResourceModel) template() string {
+	return firebaseclient.TemplateRef(m.Project.ValueString(), m.Namespace.ValueString())
+}
+
+// templateID is the ID of the template the model manages, the project ID
+// for the firebase namespace.
+func (m *RemoteConfigResourceModel) templateID() types.String {
+	namespace := m.Namespace.ValueString()
+	if namespace == "" || namespace == firebaseclient.NamespaceFirebase {
+		return types.StringValue(m.Project.ValueString())
+	}
+
+	return types.StringValue(fmt.Sprintf("projects/%s/namespaces/%s", m.Project.ValueString(), namespace))
+}
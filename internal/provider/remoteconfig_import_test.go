@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRemoteConfigImportID(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		id, project, namespace string
+	}{
+		{"my-project", "my-project", ""},
+		{"example.com:my-project", "example.com:my-project", ""},
+		{"my-project:firebase", "my-project", ""},
+		{"my-project:firebase-server", "my-project", "firebase-server"},
+		{"example.com:my-project:firebase-server", "example.com:my-project", "firebase-server"},
+		{"projects/my-project/namespaces/firebase", "my-project", ""},
+		{"projects/my-project/namespaces/firebase-server", "my-project", "firebase-server"},
+	} {
+		project, namespace, err := parseRemoteConfigImportID(tc.id)
+		if err != nil || project != tc.project || namespace != tc.namespace {
+			t.Errorf("parseRemoteConfigImportID(%q) = %q, %q, %v, want %q, %q", tc.id, project, namespace, err, tc.project, tc.namespace)
+		}
+	}
+
+	invalid := map[string]string{
+		"":                                   "is not a valid project ID",
+		"My-Project":                         "is not a valid project ID",
+		"my-project:other":                   "is not a valid project ID",
+		"projects/my-project":                "expected <project>",
+		"projects/my-project/namespaces/":    "expected <project>",
+		"projects/my-project/namespaces/foo": `unknown namespace "foo"`,
+		"projects//namespaces/firebase":      "is not a valid project ID",
+	}
+	for id, want := range invalid {
+		_, _, err := parseRemoteConfigImportID(id)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseRemoteConfigImportID(%q) = %v, want an error containing %q", id, err, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Enum values the API returns for fields left unset.
+var unspecifiedTemplateValues = map[string]string{
+	"valueType": "PARAMETER_VALUE_TYPE_UNSPECIFIED",
+	"tagColor":  "CONDITION_DISPLAY_COLOR_UNSPECIFIED",
+}
+
+// normalizeTemplateJSON returns the canonical form of a template in the
+// JSON format of the REST API: keys sorted, version metadata, nulls, empty
+// collections and unspecified enum values removed. Two templates Firebase
+// serves the same have the same canonical form.
+func normalizeTemplateJSON(raw []byte) (string, error) {
+	var template map[string]any
+	if err := json.Unmarshal(raw, &template); err != nil {
+		return "", err
+	}
+	delete(template, "version")
+	delete(template, "etag")
+
+	normalized, err := json.Marshal(pruneTemplateValue(template))
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
+
+func pruneTemplateValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			item = pruneTemplateValue(item)
+			if s, ok := item.(string); ok && unspecifiedTemplateValues[k] == s {
+				item = nil
+			}
+			if item == nil {
+				delete(v, k)
+				continue
+			}
+			v[k] = item
+		}
+		if len(v) == 0 {
+			return nil
+		}
+	case []any:
+		items := v[:0]
+		for _, item := range v {
+			if item = pruneTemplateValue(item); item != nil {
+				items = append(items, item)
+			}
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return items
+	}
+
+	return v
+}
+
+// renderedTemplateJSON is the value of rendered_template_json for a live
+// template, the template as returned when it can't be normalized.
+func renderedTemplateJSON(raw []byte) types.String {
+	normalized, err := normalizeTemplateJSON(raw)
+	if err != nil {
+		return types.StringValue(string(raw))
+	}
+
+	return types.StringValue(normalized)
+}
+
+// renderedTemplateHash is the value of template_hash for the value of
+// rendered_template_json.
+func renderedTemplateHash(rendered types.String) types.String {
+	sum := sha256.Sum256([]byte(rendered.ValueString()))
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+// maxRawResponseBytes caps raw_response_json, state is not meant for
+// templates of megabytes.
+const maxRawResponseBytes = 64 * 1024
+
+// rawResponseJSON is the value of raw_response_json for an API response,
+// null unless exposed.
+func rawResponseJSON(expose types.Bool, raw []byte) types.String {
+	if !expose.ValueBool() {
+		return types.StringNull()
+	}
+	if len(raw) > maxRawResponseBytes {
+		return types.StringValue(fmt.Sprintf("%s... (truncated, %d bytes in total)", raw[:maxRawResponseBytes], len(raw)))
+	}
+
+	return types.StringValue(string(raw))
+}
+
+// sameTemplate reports whether publishing payload would leave the live
+// template as it is.
+func sameTemplate(payload firebaseclient.RemoteConfigUpdate, live *firebaseclient.RemoteConfigRead) bool {
+	published, err := json.Marshal(payload)
+	if err != nil {
+		return false
+	}
+	a, err := normalizeTemplateJSON(published)
+	if err != nil {
+		return false
+	}
+	b, err := normalizeTemplateJSON(live.Raw)
+
+	return err == nil && a == b
+}
+
+// refreshTemplateJSON refreshes template_json from a live template. The
+// prior string is kept while it holds the same template, so formatting and
+// key order don't show up as changes.
+func refreshTemplateJSON(data *RemoteConfigResourceModel, target *firebaseclient.RemoteConfigRead) error {
+	live, err := normalizeTemplateJSON(target.Raw)
+	if err != nil {
+		return fmt.Errorf("unable to normalize live template: %w", err)
+	}
+
+	if prior, err := normalizeTemplateJSON([]byte(data.TemplateJSON.ValueString())); err == nil && prior == live {
+		return nil
+	}
+	data.TemplateJSON = types.StringValue(live)
+
+	return nil
+}
+
+// templateJSONValidator checks that a string decodes as a Remote Config
+// template.
+type templateJSONValidator struct{}
+
+func (v templateJSONValidator) Description(ctx context.Context) string {
+	return "value must be a Remote Config template in the JSON format of the REST API"
+}
+
+func (v templateJSONValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v templateJSONValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := decodeTemplateJSON(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Template JSON", err.Error())
+	}
+}
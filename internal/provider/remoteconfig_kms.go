@@ -0,0 +1,353 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// cryptoKeyPattern matches the names of Cloud KMS crypto keys.
+var cryptoKeyPattern = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+
+// encryptedValuePrefix starts the placeholders standing for encrypted
+// values.
+const encryptedValuePrefix = "kms-encrypted:"
+
+// driftedValuePlaceholder stands for the live value of an encrypted
+// parameter that is not the plaintext of its ciphertext.
+const driftedValuePlaceholder = encryptedValuePrefix + "drifted"
+
+// RemoteConfigKMSValueModel is a value encrypted with Cloud KMS, decrypted
+// by the provider when publishing.
+type RemoteConfigKMSValueModel struct {
+	CryptoKey  types.String `tfsdk:"crypto_key"`
+	Ciphertext types.String `tfsdk:"ciphertext"`
+}
+
+// kmsValueAttribute is the schema of a value encrypted with Cloud KMS.
+func kmsValueAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "Default value encrypted with Cloud KMS, e.g. from `gcloud kms encrypt ... | base64`. The provider decrypts it with its credentials, " +
+			"which need `roles/cloudkms.cryptoKeyDecrypter` on the key, and publishes the plaintext. Neither `default_value` nor the rendered template " +
+			"hold the plaintext in state, a live value that is not the plaintext plans a publish of the ciphertext again. Conflicts with `default_value` and `canary`",
+		Attributes: map[string]schema.Attribute{
+			"crypto_key": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Crypto key the value is encrypted with, e.g. `projects/my-project/locations/global/keyRings/ring/cryptoKeys/key`",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cryptoKeyPattern, "must be the name of a Cloud KMS crypto key"),
+				},
+			},
+			"ciphertext": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Base64 encoded ciphertext of the value",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// encryptedValuePlaceholder stands for an encrypted value wherever the
+// value itself must not end up: the payloads compared and hashed, the
+// rendered template and the live template kept in private state.
+func encryptedValuePlaceholder(value *RemoteConfigKMSValueModel) string {
+	if value.Ciphertext.ValueString() == "" {
+		return driftedValuePlaceholder
+	}
+	sum := sha256.Sum256([]byte(value.CryptoKey.ValueString() + "\n" + value.Ciphertext.ValueString()))
+
+	return encryptedValuePrefix + hex.EncodeToString(sum[:])
+}
+
+// encryptedValues indexes the encrypted values of the parameters of the
+// models by parameter name.
+func encryptedValues(models ...*RemoteConfigResourceModel) map[string]*RemoteConfigKMSValueModel {
+	values := make(map[string]*RemoteConfigKMSValueModel)
+	for _, data := range models {
+		if data == nil {
+			continue
+		}
+		forEachParameter(data, func(_ path.Path, name string, param RemoteConfigParameterModel) {
+			if param.DefaultValueKMS != nil {
+				values[name] = param.DefaultValueKMS
+			}
+		})
+	}
+
+	return values
+}
+
+// withEncryptedParameters returns a context with which the client redacts
+// the parameters the models encrypt from the templates it logs, since
+// published ones carry their plaintexts.
+func withEncryptedParameters(ctx context.Context, models ...*RemoteConfigResourceModel) context.Context {
+	return firebaseclient.WithSensitiveParameters(ctx, slices.Collect(maps.Keys(encryptedValues(models...)))...)
+}
+
+// decrypt returns the plaintext of an encrypted value. Plaintexts are kept
+// in memory for the rest of the run, so a value is decrypted once however
+// often it is published or compared.
+func (c *FirebaseClient) decrypt(ctx context.Context, value *RemoteConfigKMSValueModel) (string, error) {
+	key := value.CryptoKey.ValueString() + "\n" + value.Ciphertext.ValueString()
+
+	c.decryptedMu.Lock()
+	defer c.decryptedMu.Unlock()
+	if plaintext, ok := c.decrypted[key]; ok {
+		return plaintext, nil
+	}
+
+	plaintext, err := c.Decrypt(ctx, value.CryptoKey.ValueString(), value.Ciphertext.ValueString())
+	if err != nil {
+		return "", err
+	}
+	if c.decrypted == nil {
+		c.decrypted = make(map[string]string)
+	}
+	c.decrypted[key] = string(plaintext)
+
+	return string(plaintext), nil
+}
+
+// decryptValues replaces the placeholders of encrypted values in the
+// default values of a template about to be sent to the API with their
+// plaintexts. The maps of the payload are copied, not modified.
+func (c *FirebaseClient) decryptValues(ctx context.Context, payload firebaseclient.RemoteConfigUpdate, models ...*RemoteConfigResourceModel) (firebaseclient.RemoteConfigUpdate, error) {
+	placeholders := make(map[string]*RemoteConfigKMSValueModel)
+	for _, value := range encryptedValues(models...) {
+		placeholders[encryptedValuePlaceholder(value)] = value
+	}
+	if len(placeholders) == 0 {
+		return payload, nil
+	}
+
+	decryptAll := func(params map[string]firebaseclient.RemoteConfigParameter) (map[string]firebaseclient.RemoteConfigParameter, error) {
+		if params == nil {
+			return nil, nil
+		}
+		decrypted := make(map[string]firebaseclient.RemoteConfigParameter, len(params))
+		for name, param := range params {
+			if strings.HasPrefix(param.DefaultValue.Value, encryptedValuePrefix) {
+				value, ok := placeholders[param.DefaultValue.Value]
+				if !ok {
+					return nil, fmt.Errorf("the default value of parameter %s is encrypted with a ciphertext the configuration no longer declares", name)
+				}
+				plaintext, err := c.decrypt(ctx, value)
+				if err != nil {
+					return nil, fmt.Errorf("unable to decrypt the default value of parameter %s: %w", name, err)
+				}
+				param.DefaultValue.Value = plaintext
+			}
+			decrypted[name] = param
+		}
+		return decrypted, nil
+	}
+
+	parameters, err := decryptAll(payload.Parameters)
+	if err != nil {
+		return payload, err
+	}
+	groups := make(map[string]firebaseclient.RemoteConfigParameterGroup, len(payload.ParameterGroups))
+	for name, group := range payload.ParameterGroups {
+		if group.Parameters, err = decryptAll(group.Parameters); err != nil {
+			return payload, err
+		}
+		groups[name] = group
+	}
+	payload.Parameters = parameters
+	if payload.ParameterGroups != nil {
+		payload.ParameterGroups = groups
+	}
+
+	return payload, nil
+}
+
+// validationValues replaces the placeholders of encrypted values with
+// values of their parameter's type, so a template can be validated at plan
+// time without decrypting anything.
+func validationValues(payload firebaseclient.RemoteConfigUpdate) firebaseclient.RemoteConfigUpdate {
+	replaceAll := func(params map[string]firebaseclient.RemoteConfigParameter) map[string]firebaseclient.RemoteConfigParameter {
+		if params == nil {
+			return nil
+		}
+		replaced := make(map[string]firebaseclient.RemoteConfigParameter, len(params))
+		for name, param := range params {
+			if strings.HasPrefix(param.DefaultValue.Value, encryptedValuePrefix) {
+				switch param.ValueType {
+				case "NUMBER":
+					param.DefaultValue.Value = "0"
+				case "BOOLEAN":
+					param.DefaultValue.Value = "false"
+				case "JSON":
+					param.DefaultValue.Value = "{}"
+				}
+			}
+			replaced[name] = param
+		}
+		return replaced
+	}
+
+	payload.Parameters = replaceAll(payload.Parameters)
+	if payload.ParameterGroups != nil {
+		groups := make(map[string]firebaseclient.RemoteConfigParameterGroup, len(payload.ParameterGroups))
+		for name, group := range payload.ParameterGroups {
+			group.Parameters = replaceAll(group.Parameters)
+			groups[name] = group
+		}
+		payload.ParameterGroups = groups
+	}
+
+	return payload
+}
+
+// redactEncryptedValues replaces the live default values of the parameters
+// the model encrypts with their placeholders, in the parsed template and in
+// its raw JSON, before anything of it is kept. With verify the ciphertexts
+// are decrypted and a live value that is not the plaintext is replaced with
+// driftedValuePlaceholder instead.
+func (c *FirebaseClient) redactEncryptedValues(ctx context.Context, data *RemoteConfigResourceModel, target *firebaseclient.RemoteConfigRead, verify bool) error {
+	values := encryptedValues(data)
+	if len(values) == 0 {
+		return nil
+	}
+
+	placeholders := make(map[string]string)
+	redact := func(params map[string]firebaseclient.RemoteConfigParameter) error {
+		for name, param := range params {
+			value, ok := values[name]
+			if !ok {
+				continue
+			}
+			placeholder := encryptedValuePlaceholder(value)
+			if verify && placeholder != driftedValuePlaceholder {
+				plaintext, err := c.decrypt(ctx, value)
+				if err != nil {
+					return fmt.Errorf("unable to decrypt the default value of parameter %s: %w", name, err)
+				}
+				if param.DefaultValue.UseInAppDefault || param.DefaultValue.Value != plaintext {
+					placeholder = driftedValuePlaceholder
+				}
+			}
+			param.DefaultValue = firebaseclient.ConfigValue{Value: placeholder}
+			params[name] = param
+			placeholders[name] = placeholder
+		}
+		return nil
+	}
+
+	if err := redact(target.Parameters); err != nil {
+		return err
+	}
+	for _, group := range target.ParameterGroups {
+		if err := redact(group.Parameters); err != nil {
+			return err
+		}
+	}
+	if len(placeholders) == 0 || len(target.Raw) == 0 {
+		return nil
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(target.Raw, &raw); err != nil {
+		return fmt.Errorf("unable to redact encrypted values: %w", err)
+	}
+	redactRaw := func(params any) {
+		members, _ := params.(map[string]any)
+		for name, param := range members {
+			p, ok := param.(map[string]any)
+			if placeholder, redacted := placeholders[name]; ok && redacted {
+				p["defaultValue"] = map[string]any{"value": placeholder}
+			}
+		}
+	}
+	redactRaw(raw["parameters"])
+	groups, _ := raw["parameterGroups"].(map[string]any)
+	for _, group := range groups {
+		if g, ok := group.(map[string]any); ok {
+			redactRaw(g["parameters"])
+		}
+	}
+	redacted, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("unable to redact encrypted values: %w", err)
+	}
+	target.Raw = redacted
+
+	return nil
+}
+
+// encryptedValuesValidator rejects encrypted values with a canary, which
+// would route them through conditional values the provider doesn't
+// decrypt.
+type encryptedValuesValidator struct{}
+
+func (v encryptedValuesValidator) Description(ctx context.Context) string {
+	return "default_value_kms conflicts with canary"
+}
+
+func (v encryptedValuesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v encryptedValuesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	data, ok := getValidatableConfig(ctx, req)
+	if !ok || data.Canary == nil {
+		return
+	}
+
+	forEachParameter(data, func(p path.Path, name string, param RemoteConfigParameterModel) {
+		if param.DefaultValueKMS != nil {
+			resp.Diagnostics.AddAttributeError(
+				p.AtName("default_value_kms"),
+				"Encrypted Value With Canary",
+				fmt.Sprintf("Parameter %q has an encrypted default value, which can't be routed through the canary condition. Publish it without canary.", name),
+			)
+		}
+	})
+}
+
+// unsupportedKMSValueValidator rejects encrypted values in resources that
+// publish parameters without decrypting them.
+type unsupportedKMSValueValidator struct {
+	typeName string
+}
+
+func (v unsupportedKMSValueValidator) Description(ctx context.Context) string {
+	return "default_value_kms is only supported by firebaseextra_remoteconfig"
+}
+
+func (v unsupportedKMSValueValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v unsupportedKMSValueValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Encrypted Value Not Supported",
+		fmt.Sprintf("%s doesn't decrypt default_value_kms, declare the parameter in firebaseextra_remoteconfig instead.", v.typeName),
+	)
+}
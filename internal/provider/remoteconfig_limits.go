@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"unicode/utf8"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Documented limits of Remote Config templates.
+const (
+	maxTemplateParameters  = 2000
+	maxParameterKeyLength  = 256
+	maxTemplateSize        = 800 * 1024
+	maxParameterValuesSize = 1000000
+)
+
+// templateLimitErrors describes how a template exceeds the limits of the
+// API, nil when it fits.
+func templateLimitErrors(payload firebaseclient.RemoteConfigUpdate) []string {
+	var problems []string
+
+	count, valuesSize := 0, 0
+	addParameter := func(name string, param firebaseclient.RemoteConfigParameter) {
+		count++
+		if utf8.RuneCountInString(name) > maxParameterKeyLength {
+			problems = append(problems, fmt.Sprintf("parameter key %q is longer than %d characters", name, maxParameterKeyLength))
+		}
+		for _, value := range append([]firebaseclient.ConfigValue{param.DefaultValue}, slices.Collect(maps.Values(param.ConditionalValues))...) {
+			valuesSize += utf8.RuneCountInString(value.Value)
+			if value.RolloutValue != nil {
+				valuesSize += utf8.RuneCountInString(value.RolloutValue.Value)
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(payload.Parameters)) {
+		addParameter(name, payload.Parameters[name])
+	}
+	for _, group := range slices.Sorted(maps.Keys(payload.ParameterGroups)) {
+		params := payload.ParameterGroups[group].Parameters
+		for _, name := range slices.Sorted(maps.Keys(params)) {
+			addParameter(name, params[name])
+		}
+	}
+
+	if count > maxTemplateParameters {
+		problems = append(problems, fmt.Sprintf("the template has %d parameters, at most %d are accepted", count, maxTemplateParameters))
+	}
+	if valuesSize > maxParameterValuesSize {
+		problems = append(problems, fmt.Sprintf("parameter values add up to %d characters, at most %d are accepted", valuesSize, maxParameterValuesSize))
+	}
+	if jsonData, err := json.Marshal(payload); err == nil && len(jsonData) > maxTemplateSize {
+		problems = append(problems, fmt.Sprintf("the template is %d bytes, at most %d are accepted", len(jsonData), maxTemplateSize))
+	}
+
+	return problems
+}
+
+// templateLimitsValidator checks the declared template against the limits
+// of the API, which otherwise rejects it with a bare 400 at apply time.
+// Keys and values are reported on their own attribute, the totals on the
+// template.
+type templateLimitsValidator struct{}
+
+func (v templateLimitsValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("templates must have at most %d parameters, keys of at most %d characters and fit in %d bytes", maxTemplateParameters, maxParameterKeyLength, maxTemplateSize)
+}
+
+func (v templateLimitsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v templateLimitsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	data, ok := getValidatableConfig(ctx, req)
+	if !ok {
+		return
+	}
+
+	checkValue := func(p path.Path, name string, value types.String) {
+		if size := utf8.RuneCountInString(value.ValueString()); size > maxParameterValuesSize {
+			resp.Diagnostics.AddAttributeError(
+				p,
+				"Remote Config Value Too Large",
+				fmt.Sprintf("This value of parameter %q is %d characters, the parameter values of a template add up to at most %d.", name, size, maxParameterValuesSize),
+			)
+		}
+	}
+	forEachParameter(data, func(p path.Path, name string, param RemoteConfigParameterModel) {
+		if utf8.RuneCountInString(name) > maxParameterKeyLength {
+			resp.Diagnostics.AddAttributeError(
+				p,
+				"Remote Config Key Too Long",
+				fmt.Sprintf("Parameter key %q is %d characters, at most %d are accepted.", name, utf8.RuneCountInString(name), maxParameterKeyLength),
+			)
+		}
+		checkValue(p.AtName("default_value"), name, param.DefaultValue)
+		for _, condition := range slices.Sorted(maps.Keys(param.ConditionalValues)) {
+			value := param.ConditionalValues[condition]
+			checkValue(p.AtName("conditional_values").AtMapKey(condition).AtName("value"), name, value.Value)
+			if value.RolloutValue != nil {
+				checkValue(p.AtName("conditional_values").AtMapKey(condition).AtName("rollout_value").AtName("value"), name, value.RolloutValue.Value)
+			}
+		}
+	})
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values count as empty, so the totals are a lower bound until
+	// apply.
+	payload, diags := buildRemoteConfigUpdate(ctx, data)
+	if diags.HasError() {
+		return
+	}
+	attribute := path.Root("parameters")
+	if !data.TemplateJSON.IsNull() {
+		attribute = path.Root("template_json")
+	}
+	for _, problem := range templateLimitErrors(payload) {
+		resp.Diagnostics.AddAttributeError(
+			attribute,
+			"Remote Config Template Too Large",
+			fmt.Sprintf("The declared template exceeds the limits of Remote Config: %s.", problem),
+		)
+	}
+}
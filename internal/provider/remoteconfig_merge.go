@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Values of manage_mode.
+const (
+	manageModeReplace = "replace"
+	manageModeMerge   = "merge"
+)
+
+// mergeWithRemote merges the payload into the live template when the
+// resource is in merge mode, so parameters, groups and conditions the
+// configuration doesn't declare are published as they are live. The etag of
+// the model is moved to the one of the template merged with.
+func (r *RemoteConfigResource) mergeWithRemote(ctx context.Context, data, prior *RemoteConfigResourceModel, payload firebaseclient.RemoteConfigUpdate) (firebaseclient.RemoteConfigUpdate, error) {
+	if data.ManageMode.ValueString() != manageModeMerge {
+		return payload, nil
+	}
+
+	live, etag, err := r.client.GetRemoteConfig(ctx, data.template(), "")
+	if err != nil {
+		return payload, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("merge declared template into version %s", live.Version.VersionNumber))
+	data.Etag = types.StringValue(etag)
+
+	return mergeWithLive(live, payload, prior), nil
+}
+
+// mergeWithLive overlays the payload on the live template. Parameters and
+// conditions the prior model declared but the payload no longer does are
+// removed, every other live entry is kept.
+func mergeWithLive(live *firebaseclient.RemoteConfigRead, payload firebaseclient.RemoteConfigUpdate, prior *RemoteConfigResourceModel) firebaseclient.RemoteConfigUpdate {
+	declared := make(map[string]bool)
+	for name := range payload.Parameters {
+		declared[name] = true
+	}
+	for _, group := range payload.ParameterGroups {
+		for name := range group.Parameters {
+			declared[name] = true
+		}
+	}
+
+	removed := make(map[string]bool)
+	removedGroups := make(map[string]bool)
+	// Live conditions declared before or now are replaced by the payload.
+	replacedConditions := make(map[string]bool)
+	if prior != nil {
+		forEachParameter(prior, func(_ path.Path, name string, _ RemoteConfigParameterModel) {
+			if !declared[name] {
+				removed[name] = true
+			}
+		})
+		for name := range prior.ParameterGroups {
+			if _, ok := payload.ParameterGroups[name]; !ok {
+				removedGroups[name] = true
+			}
+		}
+		for _, c := range prior.Conditions {
+			replacedConditions[c.Name.ValueString()] = true
+		}
+	}
+
+	// Declared parameters are dropped from wherever they live, so moving
+	// one in or out of a group doesn't duplicate it.
+	keep := func(params map[string]firebaseclient.RemoteConfigParameter) map[string]firebaseclient.RemoteConfigParameter {
+		kept := make(map[string]firebaseclient.RemoteConfigParameter, len(params))
+		for name, param := range params {
+			if !declared[name] && !removed[name] {
+				kept[name] = param
+			}
+		}
+		return kept
+	}
+
+	merged := payload
+	merged.Preserve = live.Raw
+
+	merged.Parameters = keep(live.Parameters)
+	for name, param := range payload.Parameters {
+		merged.Parameters[name] = param
+	}
+
+	merged.ParameterGroups = make(map[string]firebaseclient.RemoteConfigParameterGroup, len(live.ParameterGroups))
+	for name, group := range live.ParameterGroups {
+		group.Parameters = keep(group.Parameters)
+		if removedGroups[name] && len(group.Parameters) == 0 {
+			continue
+		}
+		merged.ParameterGroups[name] = group
+	}
+	for name, group := range payload.ParameterGroups {
+		members := make(map[string]firebaseclient.RemoteConfigParameter)
+		for paramName, param := range merged.ParameterGroups[name].Parameters {
+			members[paramName] = param
+		}
+		for paramName, param := range group.Parameters {
+			members[paramName] = param
+		}
+		group.Parameters = members
+		merged.ParameterGroups[name] = group
+	}
+
+	// Declared conditions are evaluated first, in their declared order.
+	if payload.Conditions != nil {
+		merged.Conditions = slices.Clone(payload.Conditions)
+		for _, c := range payload.Conditions {
+			replacedConditions[c.Name] = true
+		}
+		for _, c := range live.Conditions {
+			if !replacedConditions[c.Name] {
+				merged.Conditions = append(merged.Conditions, c)
+			}
+		}
+	}
+
+	return merged
+}
+
+// keepManaged drops from a refreshed model the parameters, groups and
+// conditions the prior model doesn't declare, which merge mode leaves to
+// others.
+func keepManaged(prior, data *RemoteConfigResourceModel) {
+	managed := make(map[string]bool)
+	forEachParameter(prior, func(_ path.Path, name string, _ RemoteConfigParameterModel) {
+		managed[name] = true
+	})
+
+	for name := range data.Parameters {
+		if !managed[name] {
+			delete(data.Parameters, name)
+		}
+	}
+
+	groups := make(map[string]RemoteConfigParameterGroupModel)
+	for name, group := range data.ParameterGroups {
+		priorGroup, ok := prior.ParameterGroups[name]
+		if !ok {
+			continue
+		}
+		if len(priorGroup.Parameters) > 0 {
+			members := make(map[string]RemoteConfigParameterModel)
+			for paramName, param := range group.Parameters {
+				if managed[paramName] {
+					members[paramName] = param
+				}
+			}
+			group.Parameters = members
+		}
+		groups[name] = group
+	}
+	data.ParameterGroups = groups
+
+	if prior.Conditions != nil && data.Conditions != nil {
+		declared := make(map[string]bool, len(prior.Conditions))
+		for _, c := range prior.Conditions {
+			declared[c.Name.ValueString()] = true
+		}
+		conditions := []RemoteConfigConditionModel{}
+		for _, c := range data.Conditions {
+			if declared[c.Name.ValueString()] {
+				conditions = append(conditions, c)
+			}
+		}
+		data.Conditions = conditions
+	}
+}
+
+// templateEmpty reports whether a live template has no parameters, groups
+// or conditions.
+func templateEmpty(live *firebaseclient.RemoteConfigRead) bool {
+	return len(live.Parameters) == 0 && len(live.ParameterGroups) == 0 && len(live.Conditions) == 0
+}
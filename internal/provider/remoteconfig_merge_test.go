@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func stringParameter(value string) firebaseclient.RemoteConfigParameter {
+	return firebaseclient.RemoteConfigParameter{
+		DefaultValue: firebaseclient.ConfigValue{Value: value},
+		ValueType:    "STRING",
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestMergeWithLive(t *testing.T) {
+	t.Parallel()
+
+	live := &firebaseclient.RemoteConfigRead{
+		Conditions: []firebaseclient.RemoteConfigCondition{
+			{Name: "ios", Expression: "device.os == 'ios'"},
+			{Name: "android", Expression: "device.os == 'android'"},
+			{Name: "dropped", Expression: "true"},
+		},
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"welcome":   stringParameter("live"),
+			"unmanaged": stringParameter("kept"),
+			"removed":   stringParameter("gone"),
+			"moved":     stringParameter("top level"),
+		},
+		ParameterGroups: map[string]firebaseclient.RemoteConfigParameterGroup{
+			"others": {Parameters: map[string]firebaseclient.RemoteConfigParameter{"other": stringParameter("kept")}},
+			"old":    {Parameters: map[string]firebaseclient.RemoteConfigParameter{"old_member": stringParameter("gone")}},
+			"shared": {Parameters: map[string]firebaseclient.RemoteConfigParameter{"live_member": stringParameter("kept")}},
+		},
+		Raw: []byte(`{"parameters": {}}`),
+	}
+	prior := &RemoteConfigResourceModel{
+		Parameters: map[string]RemoteConfigParameterModel{
+			"welcome": {},
+			"removed": {},
+		},
+		ParameterGroups: map[string]RemoteConfigParameterGroupModel{
+			"old": {Parameters: map[string]RemoteConfigParameterModel{"old_member": {}}},
+		},
+		Conditions: []RemoteConfigConditionModel{{Name: types.StringValue("dropped")}},
+	}
+	payload := firebaseclient.RemoteConfigUpdate{
+		Conditions: []firebaseclient.RemoteConfigCondition{{Name: "android", Expression: "device.os == 'Android'"}},
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{"welcome": stringParameter("declared")},
+		ParameterGroups: map[string]firebaseclient.RemoteConfigParameterGroup{
+			"shared": {Parameters: map[string]firebaseclient.RemoteConfigParameter{"moved": stringParameter("grouped")}},
+		},
+	}
+
+	merged := mergeWithLive(live, payload, prior)
+
+	if got := sortedKeys(merged.Parameters); !slices.Equal(got, []string{"unmanaged", "welcome"}) {
+		t.Errorf("parameters = %v, want unmanaged and welcome", got)
+	}
+	if got := merged.Parameters["welcome"].DefaultValue.Value; got != "declared" {
+		t.Errorf("welcome = %q, want the declared value", got)
+	}
+	if got := sortedKeys(merged.ParameterGroups); !slices.Equal(got, []string{"others", "shared"}) {
+		t.Errorf("parameter groups = %v, want others and shared", got)
+	}
+	if got := sortedKeys(merged.ParameterGroups["shared"].Parameters); !slices.Equal(got, []string{"live_member", "moved"}) {
+		t.Errorf("shared members = %v, want live_member and moved", got)
+	}
+
+	var conditions []string
+	for _, c := range merged.Conditions {
+		conditions = append(conditions, c.Name+":"+c.Expression)
+	}
+	want := []string{"android:device.os == 'Android'", "ios:device.os == 'ios'"}
+	if !slices.Equal(conditions, want) {
+		t.Errorf("conditions = %v, want %v", conditions, want)
+	}
+
+	if string(merged.Preserve) != string(live.Raw) {
+		t.Errorf("preserve = %s, want the live template", merged.Preserve)
+	}
+}
+
+func TestMergeWithLiveKeepsLiveConditions(t *testing.T) {
+	t.Parallel()
+
+	live := &firebaseclient.RemoteConfigRead{
+		Conditions: []firebaseclient.RemoteConfigCondition{{Name: "ios", Expression: "device.os == 'ios'"}},
+	}
+
+	// A payload without conditions leaves the live ones untouched.
+	merged := mergeWithLive(live, firebaseclient.RemoteConfigUpdate{}, nil)
+	if merged.Conditions != nil {
+		t.Errorf("conditions = %v, want nil", merged.Conditions)
+	}
+	if len(merged.Parameters) != 0 || len(merged.ParameterGroups) != 0 {
+		t.Errorf("merged = %+v, want no parameters or groups", merged)
+	}
+}
+
+func TestKeepManaged(t *testing.T) {
+	t.Parallel()
+
+	prior := &RemoteConfigResourceModel{
+		Parameters: map[string]RemoteConfigParameterModel{"welcome": {}},
+		ParameterGroups: map[string]RemoteConfigParameterGroupModel{
+			"shared": {Parameters: map[string]RemoteConfigParameterModel{"member": {}}},
+			"empty":  {},
+		},
+		Conditions: []RemoteConfigConditionModel{{Name: types.StringValue("ios")}},
+	}
+	data := &RemoteConfigResourceModel{
+		Parameters: map[string]RemoteConfigParameterModel{"welcome": {}, "unmanaged": {}},
+		ParameterGroups: map[string]RemoteConfigParameterGroupModel{
+			"shared": {Parameters: map[string]RemoteConfigParameterModel{"member": {}, "other": {}}},
+			"empty":  {Parameters: map[string]RemoteConfigParameterModel{"live": {}}},
+			"others": {Parameters: map[string]RemoteConfigParameterModel{"other": {}}},
+		},
+		Conditions: []RemoteConfigConditionModel{{Name: types.StringValue("android")}, {Name: types.StringValue("ios")}},
+	}
+
+	keepManaged(prior, data)
+
+	if got := sortedKeys(data.Parameters); !slices.Equal(got, []string{"welcome"}) {
+		t.Errorf("parameters = %v, want welcome", got)
+	}
+	if got := sortedKeys(data.ParameterGroups); !slices.Equal(got, []string{"empty", "shared"}) {
+		t.Errorf("parameter groups = %v, want empty and shared", got)
+	}
+	if got := sortedKeys(data.ParameterGroups["shared"].Parameters); !slices.Equal(got, []string{"member"}) {
+		t.Errorf("shared members = %v, want member", got)
+	}
+	// A group declared without members keeps all of its live members.
+	if got := sortedKeys(data.ParameterGroups["empty"].Parameters); !slices.Equal(got, []string{"live"}) {
+		t.Errorf("empty members = %v, want live", got)
+	}
+	if len(data.Conditions) != 1 || data.Conditions[0].Name.ValueString() != "ios" {
+		t.Errorf("conditions = %v, want ios", data.Conditions)
+	}
+}
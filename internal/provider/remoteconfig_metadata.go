@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"text/template"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxVersionDescriptionLength is the longest version description accepted
+// by the Remote Config API.
+const maxVersionDescriptionLength = 256
+
+// remoteConfigMetadata is the structured payload recorded in the version
+// description of every template published by the provider, so external
+// systems can correlate config versions with app releases.
+type remoteConfigMetadata struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// encodeVersionDescription renders labels and annotations as the JSON
+// version description. An empty string is returned when both are empty.
+func encodeVersionDescription(metadata remoteConfigMetadata) (string, error) {
+	if len(metadata.Labels) == 0 && len(metadata.Annotations) == 0 {
+		return "", nil
+	}
+
+	description, err := json.Marshal(metadata)
+	if err != nil {
+		return "", err
+	}
+
+	if len(description) > maxVersionDescriptionLength {
+		return "", fmt.Errorf("labels and annotations encode to %d characters, the version description is limited to %d", len(description), maxVersionDescriptionLength)
+	}
+
+	return string(description), nil
+}
+
+// decodeVersionDescription parses a version description written by
+// encodeVersionDescription. The second return value is false when the
+// description was not written by the provider.
+func decodeVersionDescription(description string) (remoteConfigMetadata, bool) {
+	var metadata remoteConfigMetadata
+	if description == "" {
+		return metadata, false
+	}
+
+	if err := json.Unmarshal([]byte(description), &metadata); err != nil {
+		return remoteConfigMetadata{}, false
+	}
+
+	return metadata, true
+}
+
+// versionDescriptionData is the data of default_version_description_template.
+type versionDescriptionData struct {
+	Project     string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// parseVersionDescriptionTemplate parses default_version_description_template.
+func parseVersionDescriptionTemplate(tmpl string) (*template.Template, error) {
+	return template.New("default_version_description_template").Funcs(notifyTemplateFuncs).Option("missingkey=zero").Parse(tmpl)
+}
+
+// applyVersionDefaults records the provider default labels in the version
+// description of a payload, rendered with the provider description
+// template when there is one. Labels of the resource win over the defaults.
+func (c *FirebaseClient) applyVersionDefaults(ctx context.Context, data *RemoteConfigResourceModel, payload firebaseclient.RemoteConfigUpdate) (firebaseclient.RemoteConfigUpdate, error) {
+	if len(c.defaultLabels) == 0 && c.versionDescriptionTemplate == nil {
+		return payload, nil
+	}
+
+	labels, _ := stringMapFromValue(ctx, data.Labels)
+	annotations, _ := stringMapFromValue(ctx, data.Annotations)
+	metadata := remoteConfigMetadata{
+		Labels:      maps.Clone(c.defaultLabels),
+		Annotations: annotations,
+	}
+	if metadata.Labels == nil {
+		metadata.Labels = map[string]string{}
+	}
+	maps.Copy(metadata.Labels, labels)
+
+	description, err := encodeVersionDescription(metadata)
+	if c.versionDescriptionTemplate != nil {
+		var buf bytes.Buffer
+		err = c.versionDescriptionTemplate.Execute(&buf, versionDescriptionData{
+			Project:     data.Project.ValueString(),
+			Labels:      metadata.Labels,
+			Annotations: metadata.Annotations,
+		})
+		description = buf.String()
+		if err == nil && len(description) > maxVersionDescriptionLength {
+			err = fmt.Errorf("default_version_description_template renders %d characters, the version description is limited to %d", len(description), maxVersionDescriptionLength)
+		}
+	}
+	if err != nil {
+		return payload, err
+	}
+
+	payload.Version = nil
+	if description != "" {
+		payload.Version = &firebaseclient.RemoteConfigVersionUpdate{Description: description}
+	}
+
+	return payload, nil
+}
+
+// withoutDefaultLabels removes from refreshed labels the provider default
+// labels the resource doesn't set itself.
+func (c *FirebaseClient) withoutDefaultLabels(labels, prior map[string]string) map[string]string {
+	for k, v := range c.defaultLabels {
+		if _, declared := prior[k]; !declared && labels[k] == v {
+			delete(labels, k)
+		}
+	}
+
+	return labels
+}
+
+// stringMapValue converts a decoded metadata map into a terraform map,
+// returning null for empty maps so unset attributes don't show a diff.
+func stringMapValue(ctx context.Context, m map[string]string) (types.Map, diag.Diagnostics) {
+	if len(m) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+
+	return types.MapValueFrom(ctx, types.StringType, m)
+}
+
+// stringMapFromValue converts a terraform map into a go map.
+func stringMapFromValue(ctx context.Context, v types.Map) (map[string]string, diag.Diagnostics) {
+	if v.IsNull() || v.IsUnknown() {
+		return nil, nil
+	}
+
+	m := make(map[string]string, len(v.Elements()))
+	diags := v.ElementsAs(ctx, &m, false)
+
+	return m, diags
+}
@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RemoteConfigMetadataDataSource{}
+
+func NewRemoteConfigMetadataDataSource() datasource.DataSource {
+	return &RemoteConfigMetadataDataSource{}
+}
+
+// RemoteConfigMetadataDataSource defines the data source implementation.
+type RemoteConfigMetadataDataSource struct {
+	client *FirebaseClient
+}
+
+// RemoteConfigMetadataDataSourceModel describes the data source data model.
+type RemoteConfigMetadataDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Project       types.String `tfsdk:"project"`
+	VersionNumber types.String `tfsdk:"version_number"`
+	Description   types.String `tfsdk:"description"`
+	Labels        types.Map    `tfsdk:"labels"`
+	Annotations   types.Map    `tfsdk:"annotations"`
+}
+
+func (d *RemoteConfigMetadataDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remoteconfig_metadata"
+}
+
+func (d *RemoteConfigMetadataDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Parses the labels and annotations recorded by `firebaseextra_remoteconfig` in the version description of a Remote Config template",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project and version the metadata was read from",
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID",
+			},
+			"version_number": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Version to read, defaults to the active version",
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Raw version description",
+			},
+			"labels": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Labels recorded on the version, null when the version was not published by this provider",
+			},
+			"annotations": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Annotations recorded on the version, null when the version was not published by this provider",
+			},
+		},
+	}
+}
+
+func (d *RemoteConfigMetadataDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_remoteconfig_metadata", remoteConfigAPIScopes)
+}
+
+func (d *RemoteConfigMetadataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RemoteConfigMetadataDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target, _, err := d.client.GetRemoteConfig(ctx, data.Project.ValueString(), data.VersionNumber.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read remote config of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	metadata, _ := decodeVersionDescription(target.Version.Description)
+	labels, diags := stringMapValue(ctx, metadata.Labels)
+	resp.Diagnostics.Append(diags...)
+	annotations, diags := stringMapValue(ctx, metadata.Annotations)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), target.Version.VersionNumber))
+	data.VersionNumber = types.StringValue(target.Version.VersionNumber)
+	data.Description = types.StringValue(target.Version.Description)
+	data.Labels = labels
+	data.Annotations = annotations
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
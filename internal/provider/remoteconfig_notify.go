@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RemoteConfigNotifyModel describes the webhook called after a publish.
+type RemoteConfigNotifyModel struct {
+	URL             types.String `tfsdk:"url"`
+	Headers         types.Map    `tfsdk:"headers"`
+	PayloadTemplate types.String `tfsdk:"payload_template"`
+}
+
+// publishSummary is posted to the notify webhook, either as JSON or as the
+// data of the payload template.
+type publishSummary struct {
+	Project     string   `json:"project"`
+	Version     string   `json:"version"`
+	ChangedKeys []string `json:"changed_keys"`
+	Actor       string   `json:"actor"`
+}
+
+var notifyTemplateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// changedParameterKeys lists the parameters, top level or grouped, that are
+// added, removed or changed between two payloads.
+func changedParameterKeys(before, after firebaseclient.RemoteConfigUpdate) []string {
+	flatten := func(payload firebaseclient.RemoteConfigUpdate) map[string]firebaseclient.RemoteConfigParameter {
+		params := make(map[string]firebaseclient.RemoteConfigParameter, len(payload.Parameters))
+		for k, v := range payload.Parameters {
+			params[k] = v
+		}
+		for _, group := range payload.ParameterGroups {
+			for k, v := range group.Parameters {
+				params[k] = v
+			}
+		}
+		return params
+	}
+
+	old, current := flatten(before), flatten(after)
+	changed := []string{}
+	for k, v := range current {
+		if prev, ok := old[k]; !ok || !reflect.DeepEqual(prev, v) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range old {
+		if _, ok := current[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	slices.Sort(changed)
+
+	return changed
+}
+
+// notifyPublish posts the publish summary to the configured webhook.
+func (c *FirebaseClient) notifyPublish(ctx context.Context, notify *RemoteConfigNotifyModel, summary publishSummary) error {
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	if tmpl := notify.PayloadTemplate.ValueString(); tmpl != "" {
+		t, err := template.New("payload_template").Funcs(notifyTemplateFuncs).Parse(tmpl)
+		if err != nil {
+			return fmt.Errorf("invalid payload_template: %w", err)
+		}
+
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, summary); err != nil {
+			return fmt.Errorf("unable to render payload_template: %w", err)
+		}
+		body = buf.Bytes()
+	}
+
+	headers, diags := stringMapFromValue(ctx, notify.Headers)
+	if diags.HasError() {
+		return fmt.Errorf("invalid headers")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", notify.URL.ValueString(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("notify publish of version %s to %s", summary.Version, notify.URL.ValueString()))
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("unable to make http request to notify: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(httpResp.Body)
+		return fmt.Errorf("notify webhook responded with status %d: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,310 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RemoteConfigParameterGroupResource{}
+var _ resource.ResourceWithImportState = &RemoteConfigParameterGroupResource{}
+
+func NewRemoteConfigParameterGroupResource() resource.Resource {
+	return &RemoteConfigParameterGroupResource{}
+}
+
+// RemoteConfigParameterGroupResource manages a single parameter group of a
+// Remote Config template, leaving the rest of the template to others.
+type RemoteConfigParameterGroupResource struct {
+	client *FirebaseClient
+}
+
+// RemoteConfigParameterGroupResourceModel describes the resource data model.
+type RemoteConfigParameterGroupResourceModel struct {
+	ID          types.String                          `tfsdk:"id"`
+	Project     types.String                          `tfsdk:"project"`
+	Name        types.String                          `tfsdk:"name"`
+	Description types.String                          `tfsdk:"description"`
+	Parameters  map[string]RemoteConfigParameterModel `tfsdk:"parameters"`
+	Version     types.String                          `tfsdk:"version"`
+}
+
+func (r *RemoteConfigParameterGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remoteconfig_parameter_group"
+}
+
+func (r *RemoteConfigParameterGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	parameterAttributes := remoteConfigParameterAttributes()
+	// Encrypted values are only decrypted by firebaseextra_remoteconfig.
+	kms := kmsValueAttribute()
+	kms.MarkdownDescription = "Not supported, declare parameters with encrypted values in `firebaseextra_remoteconfig`"
+	kms.Validators = []validator.Object{
+		unsupportedKMSValueValidator{typeName: "firebaseextra_remoteconfig_parameter_group"},
+	}
+	parameterAttributes["default_value_kms"] = kms
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a single parameter group of a Remote Config template and its parameters, so a team can own its group without managing the whole template. " +
+			"Every change reads the live template and publishes it with only this group changed, starting over when another publish happens in between",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project and group name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the group",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "description",
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtMost(maxParameterDescriptionLength),
+				},
+			},
+			"parameters": schema.MapNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Parameters of the group, replacing the members already published. Declared parameters are moved into the group from wherever they live. " +
+					"When omitted only the description is managed and destroying the resource moves the members to the top level instead of deleting them",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: parameterAttributes,
+				},
+				Validators: []validator.Map{
+					parameterKeysValidator{},
+				},
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Remote Config version the group was last published or read in",
+			},
+		},
+	}
+}
+
+func (r *RemoteConfigParameterGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_remoteconfig_parameter_group", remoteConfigAPIScopes)
+}
+
+func (r *RemoteConfigParameterGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RemoteConfigParameterGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.publish(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigParameterGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RemoteConfigParameterGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	importing := data.Project.IsNull()
+	if importing {
+		// This is when we import the state, the ID is project/group
+		project, name, ok := strings.Cut(data.ID.ValueString(), "/")
+		if !ok {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected <project>/<group name>, got %q", data.ID.ValueString()))
+			return
+		}
+		data.Project = types.StringValue(project)
+		data.Name = types.StringValue(name)
+	}
+
+	target, _, err := r.client.GetRemoteConfig(ctx, data.Project.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read remote config of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	group, ok := target.ParameterGroups[data.Name.ValueString()]
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), data.Name.ValueString()))
+	data.Description = descriptionValue(group.Description, data.Description)
+	data.Version = types.StringValue(target.Version.VersionNumber)
+	if importing || data.Parameters != nil {
+		members := make(map[string]RemoteConfigParameterModel, len(group.Parameters))
+		for name, param := range group.Parameters {
+			var prior *RemoteConfigParameterModel
+			if p, ok := data.Parameters[name]; ok {
+				prior = &p
+			}
+			members[name] = parameterFromAPI(name, param, prior)
+		}
+		data.Parameters = members
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigParameterGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RemoteConfigParameterGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.publish(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigParameterGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RemoteConfigParameterGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "delete the remote config parameter group") {
+		return
+	}
+
+	name := data.Name.ValueString()
+	membersManaged := data.Parameters != nil
+	_, _, err := r.client.modifyRemoteConfig(ctx, data.Project.ValueString(), func(update *firebaseclient.RemoteConfigUpdate) error {
+		group, ok := update.ParameterGroups[name]
+		if !ok {
+			return nil
+		}
+		if !membersManaged {
+			for paramName, param := range group.Parameters {
+				update.Parameters[paramName] = param
+			}
+		}
+		delete(update.ParameterGroups, name)
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete parameter group %s of project %s: %s", name, data.Project.ValueString(), err))
+	}
+}
+
+func (r *RemoteConfigParameterGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// publish sets the group in the live template and refreshes the model from
+// the published template.
+func (r *RemoteConfigParameterGroupResource) publish(ctx context.Context, data *RemoteConfigParameterGroupResourceModel, diags *diag.Diagnostics) {
+	if !r.client.checkWritable(diags, "publish the remote config parameter group") {
+		return
+	}
+
+	name := data.Name.ValueString()
+	target, _, err := r.client.modifyRemoteConfig(ctx, data.Project.ValueString(), func(update *firebaseclient.RemoteConfigUpdate) error {
+		group := update.ParameterGroups[name]
+		group.Description = data.Description.ValueString()
+
+		if data.Parameters != nil {
+			members := make(map[string]firebaseclient.RemoteConfigParameter, len(data.Parameters))
+			for paramName, param := range data.Parameters {
+				p, err := readParameterFile(parameterToAPI(param), param)
+				if err != nil {
+					return err
+				}
+				if existing, _, ok := findParameter(update, paramName); ok && p.ConditionalValues == nil {
+					// Unmanaged conditional values stay as they are.
+					p.ConditionalValues = existing.ConditionalValues
+				}
+				if from := param.RenamedFrom.ValueString(); from != "" && from != paramName {
+					if old, _, ok := findParameter(update, from); ok {
+						if p.ConditionalValues == nil {
+							p.ConditionalValues = old.ConditionalValues
+						}
+						removeParameter(update, from)
+					}
+				}
+				removeParameter(update, paramName)
+				members[paramName] = p
+			}
+			group.Parameters = members
+		}
+
+		update.ParameterGroups[name] = group
+		return nil
+	})
+	if err != nil {
+		addModifyError(diags, fmt.Sprintf("publish parameter group %s of project %s", name, data.Project.ValueString()), err)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), name))
+	data.Version = types.StringValue(target.Version.VersionNumber)
+}
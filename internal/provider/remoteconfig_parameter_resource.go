@@ -0,0 +1,354 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RemoteConfigParameterResource{}
+var _ resource.ResourceWithImportState = &RemoteConfigParameterResource{}
+
+func NewRemoteConfigParameterResource() resource.Resource {
+	return &RemoteConfigParameterResource{}
+}
+
+// RemoteConfigParameterResource manages a single parameter of a Remote
+// Config template, leaving the rest of the template to others.
+type RemoteConfigParameterResource struct {
+	client *FirebaseClient
+}
+
+// RemoteConfigParameterResourceModel describes the resource data model.
+type RemoteConfigParameterResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Project        types.String `tfsdk:"project"`
+	ParameterGroup types.String `tfsdk:"parameter_group"`
+	Version        types.String `tfsdk:"version"`
+
+	Name              types.String                                 `tfsdk:"name"`
+	Description       types.String                                 `tfsdk:"description"`
+	ValueType         types.String                                 `tfsdk:"value_type"`
+	DefaultValue      types.String                                 `tfsdk:"default_value"`
+	DefaultValueFile  types.String                                 `tfsdk:"default_value_file"`
+	UseInAppDefault   types.Bool                                   `tfsdk:"use_in_app_default"`
+	ConditionalValues map[string]RemoteConfigConditionalValueModel `tfsdk:"conditional_values"`
+
+	DefaultValueFileSHA256 types.String `tfsdk:"default_value_file_sha256"`
+}
+
+// parameter returns the parameter attributes of the model.
+func (m *RemoteConfigParameterResourceModel) parameter() RemoteConfigParameterModel {
+	return RemoteConfigParameterModel{
+		Name:              m.Name,
+		Description:       m.Description,
+		ValueType:         m.ValueType,
+		DefaultValue:      m.DefaultValue,
+		DefaultValueFile:  m.DefaultValueFile,
+		UseInAppDefault:   m.UseInAppDefault,
+		ConditionalValues: m.ConditionalValues,
+
+		DefaultValueFileSHA256: m.DefaultValueFileSHA256,
+	}
+}
+
+// setParameter sets the parameter attributes of the model.
+func (m *RemoteConfigParameterResourceModel) setParameter(param RemoteConfigParameterModel) {
+	m.Name = param.Name
+	m.Description = param.Description
+	m.ValueType = param.ValueType
+	m.DefaultValue = param.DefaultValue
+	m.DefaultValueFile = param.DefaultValueFile
+	m.UseInAppDefault = param.UseInAppDefault
+	m.ConditionalValues = param.ConditionalValues
+	m.DefaultValueFileSHA256 = param.DefaultValueFileSHA256
+}
+
+func (r *RemoteConfigParameterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remoteconfig_parameter"
+}
+
+func (r *RemoteConfigParameterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := remoteConfigParameterAttributes()
+	// Renaming the parameter replaces the resource.
+	delete(attributes, "renamed_from")
+	// Encrypted values are only decrypted by firebaseextra_remoteconfig.
+	delete(attributes, "default_value_kms")
+	attributes["default_value"] = defaultValueAttribute("use_in_app_default", "default_value_file")
+	attributes["name"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Parameter key",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.UTF8LengthAtMost(maxParameterKeyLength),
+			stringvalidator.RegexMatches(parameterKeyPattern, parameterKeySyntax),
+		},
+	}
+	attributes["id"] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Project and parameter key",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["project"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Firebase Project ID",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["parameter_group"] = schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Existing parameter group the parameter belongs to, top level when omitted",
+	}
+	attributes["version"] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Remote Config version the parameter was last published or read in",
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a single parameter of a Remote Config template, so several configurations can own different parameters of the same project. " +
+			"Every change reads the live template and publishes it with only this parameter changed, starting over when another publish happens in between. " +
+			"Don't manage the same parameter with `firebaseextra_remoteconfig` too",
+
+		Attributes: attributes,
+	}
+}
+
+func (r *RemoteConfigParameterResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_remoteconfig_parameter", remoteConfigAPIScopes)
+}
+
+func (r *RemoteConfigParameterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RemoteConfigParameterResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.publish(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigParameterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RemoteConfigParameterResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	importing := data.Project.IsNull()
+	if importing {
+		// This is when we import the state, the ID is project/key
+		project, name, ok := strings.Cut(data.ID.ValueString(), "/")
+		if !ok {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected <project>/<parameter key>, got %q", data.ID.ValueString()))
+			return
+		}
+		data.Project = types.StringValue(project)
+		data.Name = types.StringValue(name)
+	}
+
+	target, _, err := r.client.GetRemoteConfig(ctx, data.Project.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read remote config of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+
+	if !applyRemoteParameter(&data, target, importing) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigParameterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RemoteConfigParameterResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.publish(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigParameterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RemoteConfigParameterResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "delete the remote config parameter") {
+		return
+	}
+
+	name := data.Name.ValueString()
+	_, _, err := r.client.modifyRemoteConfig(ctx, data.Project.ValueString(), func(update *firebaseclient.RemoteConfigUpdate) error {
+		removeParameter(update, name)
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete parameter %s of project %s: %s", name, data.Project.ValueString(), err))
+	}
+}
+
+func (r *RemoteConfigParameterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// publish sets the parameter in the live template and refreshes the model
+// from the published template.
+func (r *RemoteConfigParameterResource) publish(ctx context.Context, data *RemoteConfigParameterResourceModel, diags *diag.Diagnostics) {
+	if !r.client.checkWritable(diags, "publish the remote config parameter") {
+		return
+	}
+
+	name := data.Name.ValueString()
+	group := data.ParameterGroup.ValueString()
+	declared, err := readParameterFile(parameterToAPI(data.parameter()), data.parameter())
+	if err != nil {
+		diags.AddAttributeError(path.Root("default_value_file"), "Default Value File Changed", fmt.Sprintf("Unable to publish parameter %s of project %s: %s", name, data.Project.ValueString(), err))
+		return
+	}
+	target, _, err := r.client.modifyRemoteConfig(ctx, data.Project.ValueString(), func(update *firebaseclient.RemoteConfigUpdate) error {
+		// Every attempt starts from the declared parameter, the unmanaged
+		// conditional values come from the template of that attempt.
+		param := declared
+		if existing, _, ok := findParameter(update, name); ok && param.ConditionalValues == nil {
+			// Unmanaged conditional values stay as they are.
+			param.ConditionalValues = existing.ConditionalValues
+		}
+		removeParameter(update, name)
+
+		if group == "" {
+			update.Parameters[name] = param
+			return nil
+		}
+		g, ok := update.ParameterGroups[group]
+		if !ok {
+			return fmt.Errorf("parameter group %q does not exist", group)
+		}
+		if g.Parameters == nil {
+			g.Parameters = map[string]firebaseclient.RemoteConfigParameter{}
+		}
+		g.Parameters[name] = param
+		update.ParameterGroups[group] = g
+		return nil
+	})
+	if err != nil {
+		addModifyError(diags, fmt.Sprintf("publish parameter %s of project %s", name, data.Project.ValueString()), err)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), name))
+	data.Version = types.StringValue(target.Version.VersionNumber)
+}
+
+// findParameter looks a parameter up wherever it lives in a template,
+// returning the group it belongs to, "" at top level.
+func findParameter(update *firebaseclient.RemoteConfigUpdate, name string) (firebaseclient.RemoteConfigParameter, string, bool) {
+	if param, ok := update.Parameters[name]; ok {
+		return param, "", true
+	}
+	for groupName, group := range update.ParameterGroups {
+		if param, ok := group.Parameters[name]; ok {
+			return param, groupName, true
+		}
+	}
+
+	return firebaseclient.RemoteConfigParameter{}, "", false
+}
+
+// removeParameter removes a parameter wherever it lives in a template.
+func removeParameter(update *firebaseclient.RemoteConfigUpdate, name string) {
+	delete(update.Parameters, name)
+	for _, group := range update.ParameterGroups {
+		delete(group.Parameters, name)
+	}
+}
+
+// applyRemoteParameter refreshes the model from a live template. It returns
+// false when the parameter no longer exists.
+func applyRemoteParameter(data *RemoteConfigParameterResourceModel, target *firebaseclient.RemoteConfigRead, importing bool) bool {
+	live := firebaseclient.RemoteConfigUpdate{Parameters: target.Parameters, ParameterGroups: target.ParameterGroups}
+	param, group, ok := findParameter(&live, data.Name.ValueString())
+	if !ok {
+		return false
+	}
+
+	var prior *RemoteConfigParameterModel
+	if !importing {
+		p := data.parameter()
+		prior = &p
+	}
+	data.setParameter(parameterFromAPI(data.Name.ValueString(), param, prior))
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), data.Name.ValueString()))
+	data.Version = types.StringValue(target.Version.VersionNumber)
+	data.ParameterGroup = types.StringNull()
+	if group != "" {
+		data.ParameterGroup = types.StringValue(group)
+	}
+
+	return true
+}
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.ResourceWithModifyPlan = &RemoteConfigResource{}
+
+func (r *RemoteConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Nothing to plan on destroy.
+		return
+	}
+
+	// An omitted namespace is the default namespace of the provider.
+	var namespace types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("namespace"), &namespace)...)
+	if namespace.IsNull() && r.client != nil {
+		planned := types.StringNull()
+		if r.client.defaultNamespace != "" {
+			planned = types.StringValue(r.client.defaultNamespace)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("namespace"), planned)...)
+	}
+
+	// Policies get the metadata of every plan, whatever returns early below.
+	var metadata *planMetadata
+	if r.client != nil && r.client.planMetadata {
+		metadata = newPlanMetadata(ctx, req, resp.Plan)
+		defer func() { addPlanMetadata(metadata, &resp.Diagnostics) }()
+	}
+
+	var plan RemoteConfigResourceModel
+	if diags := resp.Plan.Get(ctx, &plan); diags.HasError() {
+		// Unknown collections, the plan can't be inspected yet.
+		return
+	}
+
+	if r.client != nil && r.client.descriptionMarkdown == descriptionMarkdownReject {
+		checkDescriptionMarkdown(&plan, &resp.Diagnostics)
+	}
+	if r.client != nil && len(encryptedValues(&plan)) > 0 {
+		// Cloud KMS only accepts cloud-platform.
+		r.client.checkScopes(&resp.Diagnostics, "default_value_kms", cloudPlatformAPIScopes)
+	}
+
+	var state *RemoteConfigResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &RemoteConfigResourceModel{}
+		if diags := req.State.Get(ctx, state); diags.HasError() {
+			return
+		}
+
+		if moves := parameterMoves(state, &plan); len(moves) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Remote Config Parameters Moved",
+				"The following parameters only change location and are published as moves in a single template version, "+
+					"their values and conditional values are kept:\n"+strings.Join(moves, "\n"),
+			)
+		}
+		if renames := parameterRenames(state, &plan); len(renames) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Remote Config Parameters Renamed",
+				"The following parameters are renamed, the old key is removed in the same template version that adds the new one "+
+					"and unmanaged conditional values are carried over:\n"+strings.Join(renames, "\n"),
+			)
+		}
+	}
+
+	if r.client != nil && !resp.Diagnostics.HasError() {
+		// Live templates read while planning still hold the plaintexts.
+		r.validatePlannedTemplate(withEncryptedParameters(ctx, &plan, state), req, &plan, state, metadata, &resp.Diagnostics)
+	}
+}
+
+// templateAttributes are the attributes that end up in the published
+// template.
+var templateAttributes = []string{"conditions", "parameters", "parameter_groups", "template_json", "labels", "annotations"}
+
+// templateKnown reports whether everything that ends up in the template a
+// plan publishes is known.
+func templateKnown(ctx context.Context, planned tftypes.Value) bool {
+	var raw map[string]tftypes.Value
+	if err := planned.As(&raw); err != nil {
+		return false
+	}
+	for _, name := range templateAttributes {
+		if !raw[name].IsFullyKnown() {
+			tflog.Debug(ctx, fmt.Sprintf("%s is not known yet", name))
+			return false
+		}
+	}
+
+	return true
+}
+
+// validatePlannedTemplate dry runs the publish of the planned template so
+// the API reports violations at plan time instead of halfway through an
+// apply. Failing to reach the API is only a warning, apply reports it anyway.
+// The size of the template is recorded in metadata when it is not nil.
+func (r *RemoteConfigResource) validatePlannedTemplate(ctx context.Context, req resource.ModifyPlanRequest, plan, state *RemoteConfigResourceModel, metadata *planMetadata, diags *diag.Diagnostics) {
+	if r.client.ReadOnly() {
+		tflog.Debug(ctx, "read only provider, skip validation of the planned template")
+		return
+	}
+	if plan.Project.IsUnknown() || plan.Namespace.IsUnknown() || !templateKnown(ctx, req.Plan.Raw) {
+		return
+	}
+
+	payload, d := buildRemoteConfigUpdate(ctx, plan)
+	if d.HasError() {
+		return
+	}
+
+	var private privateStateGetter
+	if state != nil {
+		lastPublished, _ := getLastPublish(ctx, req.Private)
+		if r.client.publishedUnchanged(ctx, lastPublished, plan, state, payload) {
+			return
+		}
+		private = req.Private
+	}
+
+	published, err := r.preparePublish(ctx, plan, state, payload, private)
+	if err != nil {
+		diags.AddWarning("Remote Config Not Validated", fmt.Sprintf("Unable to read the live template to validate the planned one: %s", err))
+		return
+	}
+	if r.client.descriptionMarkdown == descriptionMarkdownStrip {
+		published = stripPayloadDescriptions(published)
+	}
+	// The checks below compare placeholders with the redacted live
+	// template, the size and the limits count the contents of the files.
+	sent, err := readValueFiles(published, valueFiles(plan, state))
+	if err != nil {
+		diags.AddError("Default Value File Changed", err.Error())
+		return
+	}
+	if metadata != nil {
+		metadata.setTemplateSize(sent)
+	}
+
+	// Unmanaged parameters kept from the live template count towards the
+	// limits too.
+	if problems := templateLimitErrors(sent); len(problems) > 0 {
+		diags.AddError("Remote Config Template Too Large", fmt.Sprintf("The planned template exceeds the limits of Remote Config:\n  %s", strings.Join(problems, "\n  ")))
+		return
+	}
+
+	if !r.checkRemovals(ctx, plan, published, private, diags) || !r.checkChanges(ctx, plan, published, private, diags) {
+		return
+	}
+
+	// Encrypted values are only decrypted on apply.
+	err = r.client.ValidateRemoteConfig(ctx, plan.template(), validationValues(sent))
+	switch {
+	case errors.Is(err, firebaseclient.ErrInvalidTemplate):
+		diags.AddError("Invalid Remote Config Template", fmt.Sprintf("Firebase rejected the planned template: %s", err))
+	case err != nil:
+		diags.AddWarning("Remote Config Not Validated", fmt.Sprintf("Unable to validate the planned template: %s", err))
+	}
+}
+
+// parameterLocations indexes the parameters of a model by name with the
+// group they belong to, "" for top level parameters.
+func parameterLocations(data *RemoteConfigResourceModel) map[string]string {
+	locations := make(map[string]string)
+	for name := range data.Parameters {
+		locations[name] = ""
+	}
+	for group, g := range data.ParameterGroups {
+		for name := range g.Parameters {
+			locations[name] = group
+		}
+	}
+
+	return locations
+}
+
+// parameterMoves describes the parameters that move between top level and
+// groups, or between groups, without any other change.
+func parameterMoves(state, plan *RemoteConfigResourceModel) []string {
+	before, after := parameterLocations(state), parameterLocations(plan)
+	beforeParams, afterParams := parametersByName(state), parametersByName(plan)
+
+	var moves []string
+	for _, name := range slices.Sorted(maps.Keys(after)) {
+		from, ok := before[name]
+		if !ok || from == after[name] {
+			continue
+		}
+		if !reflect.DeepEqual(beforeParams[name], afterParams[name]) {
+			continue
+		}
+		moves = append(moves, fmt.Sprintf("  %s: %s -> %s", name, describeLocation(from), describeLocation(after[name])))
+	}
+
+	return moves
+}
+
+// parametersByName converts every parameter of a model into its API
+// representation, keyed by name.
+func parametersByName(data *RemoteConfigResourceModel) map[string]firebaseclient.RemoteConfigParameter {
+	params := make(map[string]firebaseclient.RemoteConfigParameter)
+	forEachParameter(data, func(_ path.Path, name string, param RemoteConfigParameterModel) {
+		params[name] = parameterToAPI(param)
+	})
+
+	return params
+}
+
+func describeLocation(group string) string {
+	if group == "" {
+		return "top level"
+	}
+
+	return fmt.Sprintf("group %q", group)
+}
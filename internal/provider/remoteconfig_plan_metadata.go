@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// planMetadataSummary is the summary of the warnings carrying plan
+// metadata, for policy engines to find them among the diagnostics.
+const planMetadataSummary = "Remote Config Plan Metadata"
+
+// planMetadataVersion is bumped on incompatible changes of planMetadata.
+const planMetadataVersion = 1
+
+// planMetadata describes a planned publish. Fields are only ever added, the
+// JSON encoding is what policies parse.
+type planMetadata struct {
+	FormatVersion int    `json:"format_version"`
+	Resource      string `json:"resource"`
+	Action        string `json:"action"`
+	Project       string `json:"project"`
+	Namespace     string `json:"namespace"`
+	// ChangedParameters is null when the plan doesn't know the template
+	// yet.
+	ChangedParameters []string `json:"changed_parameters"`
+	// TemplateSizeBytes is omitted when the published template can't be
+	// built at plan time, e.g. read only or with unknown values.
+	TemplateSizeBytes *int `json:"template_size_bytes,omitempty"`
+}
+
+// newPlanMetadata describes a planned publish from the plan and the prior
+// state alone, so it is reported however far the validation of the
+// planned template gets.
+func newPlanMetadata(ctx context.Context, req resource.ModifyPlanRequest, planned tfsdk.Plan) *planMetadata {
+	metadata := &planMetadata{
+		FormatVersion: planMetadataVersion,
+		Resource:      "firebaseextra_remoteconfig",
+		Action:        "update",
+		Namespace:     firebaseclient.NamespaceFirebase,
+	}
+	if req.State.Raw.IsNull() {
+		metadata.Action = "create"
+	}
+
+	var project, namespace types.String
+	planned.GetAttribute(ctx, path.Root("project"), &project)
+	planned.GetAttribute(ctx, path.Root("namespace"), &namespace)
+	metadata.Project = project.ValueString()
+	if namespace.ValueString() != "" {
+		metadata.Namespace = namespace.ValueString()
+	}
+
+	if !templateKnown(ctx, planned.Raw) {
+		return metadata
+	}
+	var plan RemoteConfigResourceModel
+	if diags := planned.Get(ctx, &plan); diags.HasError() {
+		return metadata
+	}
+	payload, diags := buildRemoteConfigUpdate(ctx, &plan)
+	if diags.HasError() {
+		return metadata
+	}
+	var previous firebaseclient.RemoteConfigUpdate
+	if !req.State.Raw.IsNull() {
+		var state RemoteConfigResourceModel
+		if diags := req.State.Get(ctx, &state); diags.HasError() {
+			return metadata
+		}
+		previous, _ = buildRemoteConfigUpdate(ctx, &state)
+	}
+	metadata.ChangedParameters = changedParameterKeys(previous, payload)
+
+	return metadata
+}
+
+// setTemplateSize records the size of the template a plan publishes. Like
+// the API, the template is counted as published, unmanaged parts included.
+func (m *planMetadata) setTemplateSize(published firebaseclient.RemoteConfigUpdate) {
+	if jsonData, err := json.Marshal(published); err == nil {
+		size := len(jsonData)
+		m.TemplateSizeBytes = &size
+	}
+}
+
+// addPlanMetadata reports the metadata of a planned publish as a warning
+// holding a single line of JSON.
+func addPlanMetadata(metadata *planMetadata, diags *diag.Diagnostics) {
+	jsonData, err := json.Marshal(metadata)
+	if err != nil {
+		return
+	}
+
+	diags.AddWarning(planMetadataSummary, string(jsonData))
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// planMetadataOf returns the plan metadata reported by a plan, decoded.
+func planMetadataOf(t *testing.T, resp *resource.ModifyPlanResponse) map[string]any {
+	t.Helper()
+
+	var found []map[string]any
+	for _, d := range resp.Diagnostics {
+		if d.Summary() != planMetadataSummary {
+			continue
+		}
+		var metadata map[string]any
+		if err := json.Unmarshal([]byte(d.Detail()), &metadata); err != nil {
+			t.Fatalf("plan metadata %q is not JSON: %v", d.Detail(), err)
+		}
+		found = append(found, metadata)
+	}
+	if len(found) != 1 {
+		t.Fatalf("diagnostics = %v, want a single plan metadata", resp.Diagnostics)
+	}
+
+	return found[0]
+}
+
+func TestPlanMetadata(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient()
+	client.planMetadata = true
+	r := &RemoteConfigResource{client: client}
+
+	resp := remoteConfigPlan(t, r, map[string]tftypes.Value{
+		"project": tftypes.NewValue(tftypes.String, "my-project"),
+		"parameters": remoteConfigParameters(t, r, map[string]map[string]tftypes.Value{
+			"welcome": {
+				"value_type":    tftypes.NewValue(tftypes.String, "STRING"),
+				"default_value": tftypes.NewValue(tftypes.String, "hello"),
+			},
+		}),
+	})
+	metadata := planMetadataOf(t, resp)
+
+	size, ok := metadata["template_size_bytes"].(float64)
+	if !ok || size <= 0 {
+		t.Errorf("template_size_bytes = %v, want the size of the template", metadata["template_size_bytes"])
+	}
+	delete(metadata, "template_size_bytes")
+	want := map[string]any{
+		"format_version":     float64(1),
+		"resource":           "firebaseextra_remoteconfig",
+		"action":             "create",
+		"project":            "my-project",
+		"namespace":          "firebase",
+		"changed_parameters": []any{"welcome"},
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Errorf("plan metadata = %v, want %v", metadata, want)
+	}
+}
+
+func TestPlanMetadataReadOnly(t *testing.T) {
+	t.Parallel()
+
+	client := &FirebaseClient{
+		Client: firebaseclient.New(
+			firebaseclient.WithHTTPClient(&http.Client{Transport: firebaseclient.NewFakeTransport("")}),
+			firebaseclient.WithReadOnly(true),
+		),
+		publishes:    &publishLog{},
+		planMetadata: true,
+	}
+	r := &RemoteConfigResource{client: client}
+
+	resp := remoteConfigPlan(t, r, map[string]tftypes.Value{
+		"project":   tftypes.NewValue(tftypes.String, "my-project"),
+		"namespace": tftypes.NewValue(tftypes.String, "firebase-server"),
+		"parameters": remoteConfigParameters(t, r, map[string]map[string]tftypes.Value{
+			"welcome": {"default_value": tftypes.NewValue(tftypes.String, "hello")},
+			"limit":   {"default_value": tftypes.NewValue(tftypes.String, "10")},
+		}),
+	})
+	metadata := planMetadataOf(t, resp)
+
+	want := map[string]any{
+		"format_version":     float64(1),
+		"resource":           "firebaseextra_remoteconfig",
+		"action":             "create",
+		"project":            "my-project",
+		"namespace":          "firebase-server",
+		"changed_parameters": []any{"limit", "welcome"},
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Errorf("plan metadata = %v, want %v", metadata, want)
+	}
+}
+
+func TestPlanMetadataUnknownTemplate(t *testing.T) {
+	t.Parallel()
+
+	client, transport := newFakeClient()
+	client.planMetadata = true
+	r := &RemoteConfigResource{client: client}
+
+	parameters := remoteConfigParameters(t, r, nil)
+	resp := remoteConfigPlan(t, r, map[string]tftypes.Value{
+		"project":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"parameters": tftypes.NewValue(parameters.Type(), tftypes.UnknownValue),
+	})
+	metadata := planMetadataOf(t, resp)
+
+	want := map[string]any{
+		"format_version":     float64(1),
+		"resource":           "firebaseextra_remoteconfig",
+		"action":             "create",
+		"project":            "",
+		"namespace":          "firebase",
+		"changed_parameters": nil,
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Errorf("plan metadata = %v, want %v", metadata, want)
+	}
+	if len(transport.sent("")) > 0 {
+		t.Errorf("requests = %v, want none for an unknown template", transport.sent(""))
+	}
+}
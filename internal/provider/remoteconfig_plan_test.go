@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// recordingTransport records the requests sent to the fake APIs.
+type recordingTransport struct {
+	fake *firebaseclient.FakeTransport
+
+	mu       sync.Mutex
+	requests []string
+	etags    []string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req.Method+" "+req.URL.String())
+	if etag := req.Header.Get("If-Match"); etag != "" {
+		t.etags = append(t.etags, etag)
+	}
+	t.mu.Unlock()
+
+	return t.fake.RoundTrip(req)
+}
+
+// sent returns the requests whose method and url contain s.
+func (t *recordingTransport) sent(s string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var matching []string
+	for _, request := range t.requests {
+		if strings.Contains(request, s) {
+			matching = append(matching, request)
+		}
+	}
+	return matching
+}
+
+// ifMatch returns the If-Match headers of the requests, in order.
+func (t *recordingTransport) ifMatch() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Clone(t.etags)
+}
+
+// newFakeClient returns a provider client talking to an in-memory fake of
+// the APIs, and the requests it sends.
+func newFakeClient() (*FirebaseClient, *recordingTransport) {
+	transport := &recordingTransport{fake: firebaseclient.NewFakeTransport("")}
+	client := &FirebaseClient{
+		Client:    firebaseclient.New(firebaseclient.WithHTTPClient(&http.Client{Transport: transport})),
+		publishes: newPublishLog(),
+	}
+
+	return client, transport
+}
+
+// objectValue returns a value of an object type with the given attributes,
+// every other attribute null.
+func objectValue(t *testing.T, typ tftypes.Type, attributes map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	object, ok := typ.(tftypes.Object)
+	if !ok {
+		t.Fatalf("%s is not an object type", typ)
+	}
+	values := make(map[string]tftypes.Value, len(object.AttributeTypes))
+	for name, attributeType := range object.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range attributes {
+		if _, ok := object.AttributeTypes[name]; !ok {
+			t.Fatalf("unknown attribute %s", name)
+		}
+		values[name] = value
+	}
+
+	return tftypes.NewValue(object, values)
+}
+
+// attributeType returns the type of the attribute at the given steps of an
+// object type, map elements being stepped into with "*".
+func attributeType(t *testing.T, typ tftypes.Type, steps ...string) tftypes.Type {
+	t.Helper()
+
+	for _, step := range steps {
+		switch current := typ.(type) {
+		case tftypes.Object:
+			typ = current.AttributeTypes[step]
+		case tftypes.Map:
+			typ = current.ElementType
+		default:
+			t.Fatalf("unable to step into %s with %s", typ, step)
+		}
+	}
+	return typ
+}
+
+// remoteConfigPlan plans the creation of a firebaseextra_remoteconfig with
+// the given attributes, computed ones left null, and returns the response.
+func remoteConfigPlan(t *testing.T, r *RemoteConfigResource, attributes map[string]tftypes.Value) *resource.ModifyPlanResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx)
+	raw := objectValue(t, typ, attributes)
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, nil)},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(ctx, req, resp)
+
+	return resp
+}
+
+// remoteConfigParameters returns the parameters attribute of a
+// firebaseextra_remoteconfig, parameters given by key as attributes.
+func remoteConfigParameters(t *testing.T, r *RemoteConfigResource, parameters map[string]map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	typ := attributeType(t, schemaResp.Schema.Type().TerraformType(ctx), "parameters")
+
+	values := make(map[string]tftypes.Value, len(parameters))
+	for name, attributes := range parameters {
+		values[name] = objectValue(t, attributeType(t, typ, "*"), attributes)
+	}
+	return tftypes.NewValue(typ, values)
+}
+
+func TestModifyPlanDoesNotDecrypt(t *testing.T) {
+	t.Parallel()
+
+	client, transport := newFakeClient()
+	r := &RemoteConfigResource{client: client}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	kmsType := attributeType(t, schemaResp.Schema.Type().TerraformType(context.Background()), "parameters", "*", "default_value_kms")
+
+	resp := remoteConfigPlan(t, r, map[string]tftypes.Value{
+		"project": tftypes.NewValue(tftypes.String, "my-project"),
+		"parameters": remoteConfigParameters(t, r, map[string]map[string]tftypes.Value{
+			"api_key": {
+				"value_type": tftypes.NewValue(tftypes.String, "NUMBER"),
+				"default_value_kms": objectValue(t, kmsType, map[string]tftypes.Value{
+					"crypto_key": tftypes.NewValue(tftypes.String, "projects/my-project/locations/global/keyRings/ring/cryptoKeys/key"),
+					"ciphertext": tftypes.NewValue(tftypes.String, "NDI="),
+				}),
+			},
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan() diagnostics = %v", resp.Diagnostics)
+	}
+	if len(resp.Diagnostics) > 0 {
+		t.Errorf("ModifyPlan() warnings = %v, want none", resp.Diagnostics)
+	}
+
+	if decrypted := transport.sent(":decrypt"); len(decrypted) > 0 {
+		t.Errorf("ModifyPlan() decrypted %v, want no Cloud KMS call", decrypted)
+	}
+	if validated := transport.sent("validateOnly=true"); len(validated) != 1 {
+		t.Errorf("ModifyPlan() validated %d templates, want 1", len(validated))
+	}
+}
+
+func TestValidationValues(t *testing.T) {
+	t.Parallel()
+
+	placeholder := encryptedValuePrefix + "0123"
+	payload := firebaseclient.RemoteConfigUpdate{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"number":  {DefaultValue: firebaseclient.ConfigValue{Value: placeholder}, ValueType: "NUMBER"},
+			"boolean": {DefaultValue: firebaseclient.ConfigValue{Value: placeholder}, ValueType: "BOOLEAN"},
+			"string":  {DefaultValue: firebaseclient.ConfigValue{Value: placeholder}, ValueType: "STRING"},
+			"plain":   {DefaultValue: firebaseclient.ConfigValue{Value: "10"}, ValueType: "NUMBER"},
+		},
+		ParameterGroups: map[string]firebaseclient.RemoteConfigParameterGroup{
+			"group": {Parameters: map[string]firebaseclient.RemoteConfigParameter{
+				"json": {DefaultValue: firebaseclient.ConfigValue{Value: placeholder}, ValueType: "JSON"},
+			}},
+		},
+	}
+
+	validated := validationValues(payload)
+
+	for name, want := range map[string]string{"number": "0", "boolean": "false", "string": placeholder, "plain": "10"} {
+		if got := validated.Parameters[name].DefaultValue.Value; got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if got := validated.ParameterGroups["group"].Parameters["json"].DefaultValue.Value; got != "{}" {
+		t.Errorf("json = %q, want {}", got)
+	}
+	if got := payload.Parameters["number"].DefaultValue.Value; got != placeholder {
+		t.Errorf("validationValues() modified the payload, number = %q", got)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// lastPublishKey is the private state key holding the record of the last
+// publish done by the provider.
+const lastPublishKey = "last_publish"
+
+// liveTemplateKey is the private state key holding the template as last
+// returned by the API, so fields the provider doesn't model survive publishes.
+const liveTemplateKey = "live_template"
+
+// privateStateGetter is satisfied by the private state of every request.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateSetter is satisfied by the private state of every response.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// lastPublish records a successful publish. It is kept in private state so
+// it doesn't show up in plans.
+type lastPublish struct {
+	TemplateHash string    `json:"template_hash"`
+	Version      string    `json:"version"`
+	PublishedAt  time.Time `json:"published_at"`
+	// ChangedParameters lists the parameters the publish added, removed or
+	// changed.
+	ChangedParameters []string `json:"changed_parameters,omitempty"`
+	// InputsHash hashes everything the published template was built from,
+	// see publishInputsHash.
+	InputsHash string `json:"inputs_hash,omitempty"`
+}
+
+// templateHash returns a stable hash of a publish payload. encoding/json
+// sorts map keys, so equal payloads always hash the same.
+func templateHash(payload firebaseclient.RemoteConfigUpdate) (string, error) {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(jsonData)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// publishInputs is everything preparePublish builds the published template
+// from besides the live template.
+type publishInputs struct {
+	Payload               firebaseclient.RemoteConfigUpdate `json:"payload"`
+	ManageMode            string                            `json:"manage_mode"`
+	IgnoreParameters      []string                          `json:"ignore_parameters"`
+	IgnoreParameterGroups []string                          `json:"ignore_parameter_groups"`
+	UnmanagedKeyPrefixes  []string                          `json:"unmanaged_key_prefixes"`
+}
+
+// publishInputsHash returns a stable hash of the declared payload with the
+// version defaults of the provider applied and of the attributes deciding
+// how it is merged with the live template, so a change of any of them
+// publishes even when the declared template is unchanged.
+func (c *FirebaseClient) publishInputsHash(ctx context.Context, data *RemoteConfigResourceModel, payload firebaseclient.RemoteConfigUpdate) (string, error) {
+	payload, err := c.applyVersionDefaults(ctx, data, payload)
+	if err != nil {
+		return "", err
+	}
+	sorted := func(v types.Set) []string {
+		var keys []string
+		if !v.IsNull() && !v.IsUnknown() {
+			v.ElementsAs(ctx, &keys, false)
+		}
+		slices.Sort(keys)
+		return keys
+	}
+
+	jsonData, err := json.Marshal(publishInputs{
+		Payload:               payload,
+		ManageMode:            data.ManageMode.ValueString(),
+		IgnoreParameters:      sorted(data.IgnoreParameters),
+		IgnoreParameterGroups: sorted(data.IgnoreParameterGroups),
+		UnmanagedKeyPrefixes:  sorted(data.UnmanagedKeyPrefixes),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(jsonData)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// publishedUnchanged reports whether the last publish of the resource, still
+// the live version of state, was built from the same inputs as data, so
+// publishing again would only create an identical version.
+func (c *FirebaseClient) publishedUnchanged(ctx context.Context, record *lastPublish, data, state *RemoteConfigResourceModel, payload firebaseclient.RemoteConfigUpdate) bool {
+	if record == nil || record.InputsHash == "" || record.Version != state.Version.ValueString() || !reflect.DeepEqual(data.Canary, state.Canary) {
+		return false
+	}
+	hash, err := c.publishInputsHash(ctx, data, payload)
+
+	return err == nil && hash == record.InputsHash
+}
+
+// getLastPublish loads the last publish record, returning nil when the
+// resource was never published by this provider version.
+func getLastPublish(ctx context.Context, private privateStateGetter) (*lastPublish, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, lastPublishKey)
+	if diags.HasError() || len(raw) == 0 {
+		return nil, diags
+	}
+
+	var record lastPublish
+	if err := json.Unmarshal(raw, &record); err != nil {
+		// An unreadable record only costs us the drift details, never fail on it.
+		return nil, diags
+	}
+
+	return &record, diags
+}
+
+// setLastPublish stores the last publish record.
+func setLastPublish(ctx context.Context, private privateStateSetter, record lastPublish) diag.Diagnostics {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to encode last publish record: %s", err))
+		return diags
+	}
+
+	return private.SetKey(ctx, lastPublishKey, raw)
+}
+
+// describeDrift explains an out of band publish relative to the last
+// publish done by the provider.
+func describeDrift(project string, record *lastPublish, remote firebaseclient.RemoteConfigVersion) string {
+	msg := fmt.Sprintf("Remote config of project %s was changed outside of Terraform: version %s replaced version %s published by the last apply", project, remote.VersionNumber, record.Version)
+	if !remote.UpdateTime.IsZero() && !record.PublishedAt.IsZero() {
+		msg += fmt.Sprintf(", %s after it", humanizeDuration(remote.UpdateTime.Sub(record.PublishedAt)))
+	}
+	if remote.UpdateUser.Email != "" {
+		msg += fmt.Sprintf(", by %s", remote.UpdateUser.Email)
+	}
+	if remote.UpdateOrigin != "" {
+		msg += fmt.Sprintf(" via %s", remote.UpdateOrigin)
+	}
+
+	return msg + ". The next apply will overwrite these changes."
+}
+
+// humanizeDuration renders a duration with the largest sensible unit.
+func humanizeDuration(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0 minutes"
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%d days", int(d/(24*time.Hour)))
+	case d >= 2*time.Hour:
+		return fmt.Sprintf("%d hours", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%d minutes", int(d/time.Minute))
+	}
+}
+
+// recordPublish stores the last publish record for a freshly published payload.
+func recordPublish(ctx context.Context, private privateStateSetter, payload firebaseclient.RemoteConfigUpdate, inputsHash string, version string, changed []string) diag.Diagnostics {
+	hash, err := templateHash(payload)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to hash published template: %s", err))
+		return diags
+	}
+
+	return setLastPublish(ctx, private, lastPublish{
+		TemplateHash:      hash,
+		Version:           version,
+		PublishedAt:       time.Now().UTC(),
+		ChangedParameters: changed,
+		InputsHash:        inputsHash,
+	})
+}
+
+// describeVersionChain lists every version published out of band since the
+// last publish done by the provider, newest first.
+func describeVersionChain(project string, record *lastPublish, versions []firebaseclient.RemoteConfigVersion) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Remote config of project %s was changed outside of Terraform after version %s published by the last apply:", project, record.Version)
+	for _, v := range versions {
+		fmt.Fprintf(&b, "\n  - version %s", v.VersionNumber)
+		if !v.UpdateTime.IsZero() {
+			fmt.Fprintf(&b, " at %s", v.UpdateTime.Format(time.RFC3339))
+		}
+		if v.UpdateUser.Email != "" {
+			fmt.Fprintf(&b, " by %s", v.UpdateUser.Email)
+		}
+		if v.UpdateOrigin != "" {
+			fmt.Fprintf(&b, " via %s", v.UpdateOrigin)
+		}
+		if v.UpdateType != "" {
+			fmt.Fprintf(&b, " (%s)", v.UpdateType)
+		}
+	}
+	b.WriteString("\nThe next apply will overwrite these changes.")
+
+	return b.String()
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPublishedUnchanged(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	client := &FirebaseClient{}
+	state := &RemoteConfigResourceModel{
+		Version:    types.StringValue("7"),
+		ManageMode: types.StringValue(manageModeReplace),
+	}
+	payload := firebaseclient.RemoteConfigUpdate{Parameters: map[string]firebaseclient.RemoteConfigParameter{"welcome": stringParameter("hello")}}
+	inputsHash, err := client.publishInputsHash(ctx, state, payload)
+	if err != nil {
+		t.Fatalf("publishInputsHash() = %v", err)
+	}
+	record := &lastPublish{Version: "7", InputsHash: inputsHash}
+
+	tests := map[string]struct {
+		record  *lastPublish
+		data    func(data *RemoteConfigResourceModel)
+		payload firebaseclient.RemoteConfigUpdate
+
+		want bool
+	}{
+		"unchanged": {
+			record: record, payload: payload,
+			want: true,
+		},
+		"never published": {
+			payload: payload,
+		},
+		"published before inputs were recorded": {
+			record: &lastPublish{Version: "7"}, payload: payload,
+		},
+		"published since": {
+			record: &lastPublish{Version: "6", InputsHash: inputsHash}, payload: payload,
+		},
+		"template changed": {
+			record: record,
+			payload: firebaseclient.RemoteConfigUpdate{Parameters: map[string]firebaseclient.RemoteConfigParameter{
+				"welcome": stringParameter("bonjour"),
+			}},
+		},
+		"manage mode changed": {
+			record: record, payload: payload,
+			data: func(data *RemoteConfigResourceModel) { data.ManageMode = types.StringValue(manageModeMerge) },
+		},
+		"canary added": {
+			record: record, payload: payload,
+			data: func(data *RemoteConfigResourceModel) {
+				data.Canary = &RemoteConfigCanaryModel{Condition: types.StringValue("canary"), Percent: types.Int64Value(10)}
+			},
+		},
+		// force_publish only changes the etag published over, the template
+		// it would publish is the live one.
+		"force publish": {
+			record: record, payload: payload,
+			data: func(data *RemoteConfigResourceModel) { data.ForcePublish = types.BoolValue(true) },
+			want: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data := *state
+			if test.data != nil {
+				test.data(&data)
+			}
+			if got := client.publishedUnchanged(ctx, test.record, &data, state, test.payload); got != test.want {
+				t.Errorf("publishedUnchanged() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestOverriddenVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		planned, published string
+
+		want string
+	}{
+		"next version":     {planned: "4", published: "5", want: ""},
+		"one overridden":   {planned: "4", published: "6", want: "version 5"},
+		"many overridden":  {planned: "4", published: "9", want: "versions 5 to 8"},
+		"unknown planned":  {planned: "", published: "9", want: ""},
+		"invalid versions": {planned: "4", published: "latest", want: ""},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := overriddenVersions(test.planned, test.published); got != test.want {
+				t.Errorf("overriddenVersions(%q, %q) = %q, want %q", test.planned, test.published, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// renamedParameters maps the parameters of a model that set renamed_from
+// to the key they are renamed from.
+func renamedParameters(data *RemoteConfigResourceModel) map[string]string {
+	renames := make(map[string]string)
+	forEachParameter(data, func(_ path.Path, name string, param RemoteConfigParameterModel) {
+		if from := param.RenamedFrom.ValueString(); from != "" && from != name {
+			renames[name] = from
+		}
+	})
+
+	return renames
+}
+
+// applyRenames removes the keys renamed parameters are renamed from, in the
+// same publish that adds them under their new key. Conditional values the
+// renamed parameter leaves unmanaged are copied from the old key, so they
+// survive the rename.
+func (r *RemoteConfigResource) applyRenames(ctx context.Context, data *RemoteConfigResourceModel, payload firebaseclient.RemoteConfigUpdate, private privateStateGetter) (firebaseclient.RemoteConfigUpdate, error) {
+	renames := renamedParameters(data)
+	if len(renames) == 0 {
+		return payload, nil
+	}
+
+	live, err := r.liveTemplate(ctx, data, private)
+	if err != nil {
+		return payload, err
+	}
+
+	return renameParameters(live, payload, renames), nil
+}
+
+func renameParameters(live *firebaseclient.RemoteConfigRead, payload firebaseclient.RemoteConfigUpdate, renames map[string]string) firebaseclient.RemoteConfigUpdate {
+	// Copy the maps, the payload may share them with the declared template.
+	payload.Parameters = maps.Clone(payload.Parameters)
+	payload.ParameterGroups = maps.Clone(payload.ParameterGroups)
+	for name, group := range payload.ParameterGroups {
+		group.Parameters = maps.Clone(group.Parameters)
+		payload.ParameterGroups[name] = group
+	}
+
+	liveTemplate := firebaseclient.RemoteConfigUpdate{Parameters: live.Parameters, ParameterGroups: live.ParameterGroups}
+	for _, name := range slices.Sorted(maps.Keys(renames)) {
+		from := renames[name]
+		old, _, ok := findParameter(&liveTemplate, from)
+		if !ok {
+			// Already renamed, or the old key never existed.
+			continue
+		}
+
+		if param, group, ok := findParameter(&payload, name); ok && param.ConditionalValues == nil {
+			param.ConditionalValues = old.ConditionalValues
+			if group == "" {
+				payload.Parameters[name] = param
+			} else {
+				payload.ParameterGroups[group].Parameters[name] = param
+			}
+		}
+		removeParameter(&payload, from)
+	}
+
+	return payload
+}
+
+// parameterRenames describes the renames a plan publishes, the parameters
+// whose renamed_from key is in the prior state.
+func parameterRenames(state, plan *RemoteConfigResourceModel) []string {
+	before := parameterLocations(state)
+	renames := renamedParameters(plan)
+
+	var described []string
+	for _, name := range slices.Sorted(maps.Keys(renames)) {
+		from := renames[name]
+		if _, ok := before[from]; !ok {
+			continue
+		}
+		if _, ok := before[name]; ok {
+			continue
+		}
+		described = append(described, fmt.Sprintf("  %s -> %s", from, name))
+	}
+
+	return described
+}
+
+// renamedFromValidator checks that a renamed_from key is not declared as a
+// parameter itself, nor renamed into two parameters.
+type renamedFromValidator struct{}
+
+func (v renamedFromValidator) Description(ctx context.Context) string {
+	return "renamed_from must name a key that is not declared"
+}
+
+func (v renamedFromValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v renamedFromValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	data, ok := getValidatableConfig(ctx, req)
+	if !ok {
+		return
+	}
+
+	declared := parameterLocations(data)
+	renamedTo := make(map[string]string)
+	forEachParameter(data, func(p path.Path, name string, param RemoteConfigParameterModel) {
+		from := param.RenamedFrom.ValueString()
+		if from == "" || from == name {
+			return
+		}
+		if _, ok := declared[from]; ok {
+			resp.Diagnostics.AddAttributeError(
+				p.AtName("renamed_from"),
+				"Renamed Parameter Declared",
+				fmt.Sprintf("Parameter %q is renamed from %q, which is still declared. Remove the declaration of %q.", name, from, from),
+			)
+			return
+		}
+		if other, ok := renamedTo[from]; ok {
+			resp.Diagnostics.AddAttributeError(
+				p.AtName("renamed_from"),
+				"Parameter Renamed Twice",
+				fmt.Sprintf("Parameters %q and %q are both renamed from %q.", other, name, from),
+			)
+			return
+		}
+		renamedTo[from] = name
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRenamedParameters(t *testing.T) {
+	t.Parallel()
+
+	data := &RemoteConfigResourceModel{
+		Parameters: map[string]RemoteConfigParameterModel{
+			"welcome_text": {RenamedFrom: types.StringValue("welcome")},
+			"limit":        {RenamedFrom: types.StringNull()},
+			"same":         {RenamedFrom: types.StringValue("same")},
+		},
+		ParameterGroups: map[string]RemoteConfigParameterGroupModel{
+			"onboarding": {Parameters: map[string]RemoteConfigParameterModel{
+				"steps": {RenamedFrom: types.StringValue("onboarding_steps")},
+			}},
+		},
+	}
+
+	got := renamedParameters(data)
+	if len(got) != 2 || got["welcome_text"] != "welcome" || got["steps"] != "onboarding_steps" {
+		t.Errorf("renamedParameters() = %v", got)
+	}
+}
+
+func TestRenameParameters(t *testing.T) {
+	t.Parallel()
+
+	live := &firebaseclient.RemoteConfigRead{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"welcome": {
+				DefaultValue:      firebaseclient.ConfigValue{Value: "hello"},
+				ConditionalValues: map[string]firebaseclient.ConfigValue{"ios": {Value: "hi"}},
+			},
+			"limit": {
+				DefaultValue:      firebaseclient.ConfigValue{Value: "10"},
+				ConditionalValues: map[string]firebaseclient.ConfigValue{"ios": {Value: "5"}},
+			},
+		},
+		ParameterGroups: map[string]firebaseclient.RemoteConfigParameterGroup{
+			"onboarding": {Parameters: map[string]firebaseclient.RemoteConfigParameter{
+				"onboarding_steps": {ConditionalValues: map[string]firebaseclient.ConfigValue{"ios": {Value: "2"}}},
+			}},
+		},
+	}
+	payload := firebaseclient.RemoteConfigUpdate{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"welcome_text": stringParameter("hello"),
+			"max":          {DefaultValue: firebaseclient.ConfigValue{Value: "10"}, ConditionalValues: map[string]firebaseclient.ConfigValue{}},
+			// Merge mode publishes the live parameters along the declared ones.
+			"limit": live.Parameters["limit"],
+		},
+		ParameterGroups: map[string]firebaseclient.RemoteConfigParameterGroup{
+			"onboarding": {Parameters: map[string]firebaseclient.RemoteConfigParameter{
+				"steps":            {DefaultValue: firebaseclient.ConfigValue{Value: "3"}},
+				"onboarding_steps": live.ParameterGroups["onboarding"].Parameters["onboarding_steps"],
+			}},
+		},
+	}
+	renames := map[string]string{
+		"welcome_text": "welcome",
+		"max":          "limit",
+		"steps":        "onboarding_steps",
+		"fresh":        "never_existed",
+	}
+
+	renamed := renameParameters(live, payload, renames)
+
+	if got := sortedKeys(renamed.Parameters); !slices.Equal(got, []string{"max", "welcome_text"}) {
+		t.Errorf("parameters = %v, want the old keys removed", got)
+	}
+	if got := sortedKeys(renamed.ParameterGroups["onboarding"].Parameters); !slices.Equal(got, []string{"steps"}) {
+		t.Errorf("onboarding members = %v, want the old key removed", got)
+	}
+	// Unmanaged conditional values are carried over from the old key.
+	if got := renamed.Parameters["welcome_text"].ConditionalValues["ios"].Value; got != "hi" {
+		t.Errorf("welcome_text ios = %q, want hi", got)
+	}
+	if got := renamed.ParameterGroups["onboarding"].Parameters["steps"].ConditionalValues["ios"].Value; got != "2" {
+		t.Errorf("steps ios = %q, want 2", got)
+	}
+	// Managed conditional values are published as declared.
+	if got := renamed.Parameters["max"].ConditionalValues; len(got) != 0 {
+		t.Errorf("max conditional values = %v, want none", got)
+	}
+
+	// The payload may share its maps with the declared template.
+	if _, ok := payload.Parameters["limit"]; !ok {
+		t.Error("renameParameters() modified the parameters of the payload")
+	}
+	if _, ok := payload.ParameterGroups["onboarding"].Parameters["onboarding_steps"]; !ok {
+		t.Error("renameParameters() modified the groups of the payload")
+	}
+	if payload.Parameters["welcome_text"].ConditionalValues != nil {
+		t.Error("renameParameters() modified a parameter of the payload")
+	}
+}
+
+func TestParameterRenames(t *testing.T) {
+	t.Parallel()
+
+	state := &RemoteConfigResourceModel{
+		Parameters: map[string]RemoteConfigParameterModel{"welcome": {}, "limit": {}, "max": {}},
+		ParameterGroups: map[string]RemoteConfigParameterGroupModel{
+			"onboarding": {Parameters: map[string]RemoteConfigParameterModel{"onboarding_steps": {}}},
+		},
+	}
+	plan := &RemoteConfigResourceModel{
+		Parameters: map[string]RemoteConfigParameterModel{
+			"welcome_text": {RenamedFrom: types.StringValue("welcome")},
+			// Already renamed by a previous apply.
+			"max": {RenamedFrom: types.StringValue("limit")},
+			// The old key is not in state, nothing is renamed.
+			"fresh": {RenamedFrom: types.StringValue("never_existed")},
+			"steps": {RenamedFrom: types.StringValue("onboarding_steps")},
+		},
+	}
+
+	got := parameterRenames(state, plan)
+	want := []string{"  onboarding_steps -> steps", "  welcome -> welcome_text"}
+	if !slices.Equal(got, want) {
+		t.Errorf("parameterRenames() = %q, want %q", got, want)
+	}
+}
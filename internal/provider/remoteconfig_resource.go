@@ -4,38 +4,54 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"slices"
 	"strings"
 	"time"
 
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"golang.org/x/oauth2/google"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RemoteConfigResource{}
 var _ resource.ResourceWithImportState = &RemoteConfigResource{}
+var _ resource.ResourceWithConfigValidators = &RemoteConfigResource{}
+
+// Values of on_destroy.
+const (
+	onDestroyAbandon = "abandon"
+	onDestroyClear   = "clear"
+)
+
+// Values of on_create.
+const (
+	onCreateOverwrite    = "overwrite"
+	onCreateFailIfExists = "fail_if_exists"
+	onCreateAdopt        = "adopt"
+)
+
+// defaultRemoteConfigTimeout bounds each operation on the template unless
+// the timeouts block sets otherwise.
+const defaultRemoteConfigTimeout = 5 * time.Minute
 
 func NewRemoteConfigResource() resource.Resource {
 	return &RemoteConfigResource{}
 }
 
-type FirebaseClient struct {
-	*http.Client
-	accesstoken string
-	endpoint    string
-}
-
 // RemoteConfigResource defines the resource implementation.
 type RemoteConfigResource struct {
 	client *FirebaseClient
@@ -43,24 +59,94 @@ type RemoteConfigResource struct {
 
 // RemoteConfigResourceModel describes the resource data model.
 type RemoteConfigResourceModel struct {
-	ID              types.String                               `tfsdk:"id"`
-	Project         types.String                               `tfsdk:"project"`
-	Version         types.String                               `tfsdk:"version"`
-	Etag            types.String                               `tfsdk:"etag"`
-	Parameters      []RemoteConfigParameterModel               `tfsdk:"parameters"`
-	ParameterGroups map[string]RemoteConfigParameterGroupModel `tfsdk:"parameter_groups"`
+	ID               types.String                               `tfsdk:"id"`
+	Project          types.String                               `tfsdk:"project"`
+	Namespace        types.String                               `tfsdk:"namespace"`
+	Version          types.String                               `tfsdk:"version"`
+	Etag             types.String                               `tfsdk:"etag"`
+	Conditions       []RemoteConfigConditionModel               `tfsdk:"conditions"`
+	Parameters       map[string]RemoteConfigParameterModel      `tfsdk:"parameters"`
+	ParameterGroups  map[string]RemoteConfigParameterGroupModel `tfsdk:"parameter_groups"`
+	TemplateJSON     types.String                               `tfsdk:"template_json"`
+	Labels           types.Map                                  `tfsdk:"labels"`
+	Annotations      types.Map                                  `tfsdk:"annotations"`
+	AuditDrift       types.Bool                                 `tfsdk:"audit_drift"`
+	Notify           *RemoteConfigNotifyModel                   `tfsdk:"notify"`
+	OnDestroy        types.String                               `tfsdk:"on_destroy"`
+	OnCreate         types.String                               `tfsdk:"on_create"`
+	ConflictStrategy types.String                               `tfsdk:"conflict_strategy"`
+	ForcePublish     types.Bool                                 `tfsdk:"force_publish"`
+	ManageMode       types.String                               `tfsdk:"manage_mode"`
+
+	MaxParameterRemoval     types.Int64 `tfsdk:"max_parameter_removal"`
+	AllowDestructivePublish types.Bool  `tfsdk:"allow_destructive_publish"`
+	MaxChangedParameters    types.Int64 `tfsdk:"max_changed_parameters"`
+	AllowLargePublish       types.Bool  `tfsdk:"allow_large_publish"`
+
+	Canary *RemoteConfigCanaryModel `tfsdk:"canary"`
+
+	IgnoreParameters      types.Set `tfsdk:"ignore_parameters"`
+	IgnoreParameterGroups types.Set `tfsdk:"ignore_parameter_groups"`
+	UnmanagedKeyPrefixes  types.Set `tfsdk:"unmanaged_key_prefixes"`
+
+	TemplateSizeBytes     types.Int64  `tfsdk:"template_size_bytes"`
+	LastPublishDurationMs types.Int64  `tfsdk:"last_publish_duration_ms"`
+	ChangedParameters     types.List   `tfsdk:"changed_parameters"`
+	RenderedTemplateJSON  types.String `tfsdk:"rendered_template_json"`
+	TemplateHash          types.String `tfsdk:"template_hash"`
+	ExposeRawResponse     types.Bool   `tfsdk:"expose_raw_response"`
+	RawResponseJSON       types.String `tfsdk:"raw_response_json"`
+
+	UpdateTime   types.String `tfsdk:"update_time"`
+	UpdateUser   types.String `tfsdk:"update_user"`
+	UpdateOrigin types.String `tfsdk:"update_origin"`
+	UpdateType   types.String `tfsdk:"update_type"`
+	FencingToken types.String `tfsdk:"fencing_token"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type RemoteConfigParameterGroupModel struct {
-	Description types.String                          `tfsdk:"description",json:"description"`
-	Parameters  map[string]RemoteConfigParameterModel `tfsdk:"parameters",json:"parameters"`
+	Description types.String                          `tfsdk:"description"`
+	Parameters  map[string]RemoteConfigParameterModel `tfsdk:"parameters"`
 }
 
 type RemoteConfigParameterModel struct {
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	ValueType    types.String `tfsdk:"value_type"`
-	DefaultValue types.String `tfsdk:"default_value"`
+	Name              types.String                                 `tfsdk:"name"`
+	Description       types.String                                 `tfsdk:"description"`
+	ValueType         types.String                                 `tfsdk:"value_type"`
+	DefaultValue      types.String                                 `tfsdk:"default_value"`
+	DefaultValueFile  types.String                                 `tfsdk:"default_value_file"`
+	UseInAppDefault   types.Bool                                   `tfsdk:"use_in_app_default"`
+	ConditionalValues map[string]RemoteConfigConditionalValueModel `tfsdk:"conditional_values"`
+	RenamedFrom       types.String                                 `tfsdk:"renamed_from"`
+
+	DefaultValueKMS *RemoteConfigKMSValueModel `tfsdk:"default_value_kms"`
+
+	DefaultValueFileSHA256 types.String `tfsdk:"default_value_file_sha256"`
+}
+
+type RemoteConfigConditionalValueModel struct {
+	Value                types.String                           `tfsdk:"value"`
+	PersonalizationValue *RemoteConfigPersonalizationValueModel `tfsdk:"personalization_value"`
+	RolloutValue         *RemoteConfigRolloutValueModel         `tfsdk:"rollout_value"`
+}
+
+type RemoteConfigRolloutValueModel struct {
+	RolloutID types.String `tfsdk:"rollout_id"`
+	Value     types.String `tfsdk:"value"`
+	Percent   types.Int64  `tfsdk:"percent"`
+}
+
+type RemoteConfigPersonalizationValueModel struct {
+	PersonalizationID types.String `tfsdk:"personalization_id"`
+}
+
+type RemoteConfigConditionModel struct {
+	Name       types.String                     `tfsdk:"name"`
+	Expression types.String                     `tfsdk:"expression"`
+	Match      *RemoteConfigConditionMatchModel `tfsdk:"match"`
+	TagColor   types.String                     `tfsdk:"tag_color"`
 }
 
 func (r *RemoteConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -71,6 +157,7 @@ func (r *RemoteConfigResource) Schema(ctx context.Context, req resource.SchemaRe
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Remote Config represents a remoteconfig item in FireBase",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -85,72 +172,304 @@ func (r *RemoteConfigResource) Schema(ctx context.Context, req resource.SchemaRe
 				Computed:            true,
 				MarkdownDescription: "Published etag version",
 			},
+			"template_size_bytes": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Size in bytes of the live template as returned by the API, useful to alert before reaching the template size limit",
+			},
+			"last_publish_duration_ms": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Duration in milliseconds of the last publish made by this resource, null when imported",
+			},
+			"changed_parameters": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Sorted keys of the parameters added, removed or changed by the last publish made by this resource, null when imported",
+			},
+			"update_time": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 time the live version was published",
+			},
+			"update_user": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Email of who published the live version",
+			},
+			"update_origin": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Where the live version was published from, e.g. `CONSOLE` or `REST_API`",
+			},
+			"update_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "How the live version was published, e.g. `INCREMENTAL_UPDATE`, `FORCED_UPDATE` or `ROLLBACK`",
+			},
+			"fencing_token": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "`<project>/<namespace>/<version>/<etag>` of the live version, for deployment pipelines to check they observe exactly the version published, " +
+					"e.g. against the `ETag` header of the REST API, before proceeding",
+			},
+			"rendered_template_json": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "The live template as published and read back from the API, in the JSON format of the REST API with sorted keys and without version metadata, " +
+					"for policy checks and CI artifacts",
+			},
+			"template_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hex encoded SHA-256 of `rendered_template_json`, changing only when the content of the live template does, e.g. to trigger other resources on template changes",
+			},
+			"expose_raw_response": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Record the last API response in `raw_response_json`, to debug how the template maps to the attributes without TRACE logs",
+			},
+			"raw_response_json": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: fmt.Sprintf("The template exactly as last returned by the API when `expose_raw_response` is true, truncated to %d KiB", maxRawResponseBytes/1024),
+			},
 
 			"project": schema.StringAttribute{
 				MarkdownDescription: "Firebase Project ID",
 				Required:            true,
 			},
-			"parameters": schema.ListNestedAttribute{
-				Required: true,
+			"namespace": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Namespace of the template, `" + firebaseclient.NamespaceFirebase + "` for client apps or `" + firebaseclient.NamespaceFirebaseServer + "` for server side Remote Config. Defaults to the `default_namespace` of the provider, null standing for `" + firebaseclient.NamespaceFirebase + "`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(firebaseclient.RemoteConfigNamespaces...),
+				},
+			},
+			"conditions": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Conditions referenced by conditional values, evaluated in order. When omitted the conditions already published are kept",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
 							Required:            true,
-							MarkdownDescription: "name",
+							MarkdownDescription: "Name referenced by conditional values",
 						},
-						"default_value": schema.StringAttribute{
-							Required:            true,
-							MarkdownDescription: "default_value",
-						},
-						"description": schema.StringAttribute{
-							Required:            true,
-							MarkdownDescription: "description",
-						},
-						"value_type": schema.StringAttribute{
-							Required:            true,
-							MarkdownDescription: "value type",
+						"expression": conditionExpressionAttribute(),
+						"match":      conditionMatchAttribute(),
+						"tag_color": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Color the console displays the condition with, one of " + strings.Join(firebaseclient.ConditionTagColors, ", "),
+							Validators: []validator.String{
+								stringvalidator.OneOf(firebaseclient.ConditionTagColors...),
+							},
 						},
 					},
 				},
 			},
+			"parameters": schema.MapNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Top level parameters keyed by name. When omitted or empty the template has no top level parameters, e.g. when every parameter is in a group",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: remoteConfigParameterAttributes(),
+				},
+				Validators: []validator.Map{
+					parameterKeysValidator{},
+				},
+			},
+			"template_json": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The whole template in the JSON format of the REST API, e.g. `file(\"remoteconfig.json\")` of a console export, published verbatim instead of the HCL attributes. " +
+					"Differences in formatting, key order and version metadata are not changes. Conflicts with `conditions`, `parameter_groups` and the attributes reshaping the published template",
+				Validators: []validator.String{
+					templateJSONValidator{},
+				},
+			},
 
 			"parameter_groups": schema.MapNestedAttribute{
 				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"description": schema.StringAttribute{
-							Required:            true,
+							Optional:            true,
 							MarkdownDescription: "description",
+							Validators: []validator.String{
+								stringvalidator.UTF8LengthAtMost(maxParameterDescriptionLength),
+							},
 						},
 						"parameters": schema.MapNestedAttribute{
-							Required: true,
+							Optional:            true,
+							MarkdownDescription: "Parameters of the group. When omitted or empty only the group description is managed and the members already published in the group are kept",
 							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"name": schema.StringAttribute{
-										Required:            true,
-										MarkdownDescription: "name",
-									},
-									"default_value": schema.StringAttribute{
-										Required:            true,
-										MarkdownDescription: "default_value",
-									},
-									"description": schema.StringAttribute{
-										Required:            true,
-										MarkdownDescription: "description",
-									},
-									"value_type": schema.StringAttribute{
-										Required:            true,
-										MarkdownDescription: "value type",
-									},
-								},
+								Attributes: remoteConfigParameterAttributes(),
 							},
+							Validators: []validator.Map{
+								parameterKeysValidator{},
+							},
+						},
+					},
+				},
+			},
+			// end parameter group
+
+			"labels": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Labels recorded as JSON in the version description of every publish, so release dashboards can correlate config versions with app releases",
+			},
+			"annotations": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Annotations recorded as JSON in the version description next to `labels`",
+			},
+			"audit_drift": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When true, refresh lists every version published since the last apply and names who published it and from where",
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "What destroying the resource does to the template: `abandon` (default) leaves it live in Firebase, `clear` publishes an empty template over the last known etag",
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyAbandon, onDestroyClear),
+				},
+			},
+			"on_create": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "What creating the resource does when the project already has a template: `overwrite` (default) replaces it whatever it is, " +
+					"`fail_if_exists` fails unless the template is empty, so a project managed elsewhere is not clobbered, " +
+					"`adopt` takes over the live template: the parts the configuration leaves unmanaged are kept, nothing is published when the configuration matches it, " +
+					"and the publish fails if the template changes in the meantime",
+				Validators: []validator.String{
+					stringvalidator.OneOf(onCreateOverwrite, onCreateFailIfExists, onCreateAdopt),
+				},
+			},
+			"conflict_strategy": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "What an update does when the template changed since the last refresh, e.g. published from the console or by a concurrent apply: " +
+					"`fail` (default) fails the apply, `retry` reads the template again and publishes over its new etag as long as only parts the configuration leaves unmanaged changed",
+				Validators: []validator.String{
+					stringvalidator.OneOf(conflictStrategyFail, conflictStrategyRetry),
+				},
+			},
+			"force_publish": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Publish updates over whatever template is live, discarding changes made since the last refresh, e.g. in the console, instead of failing the apply. " +
+					"A warning lists the versions that were overridden",
+			},
+			"max_parameter_removal": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Most parameters of the live template a publish may remove. A publish removing more fails with the list of parameters it would remove, " +
+					"e.g. to protect against wiping a template after a bad import. No limit when omitted",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"allow_destructive_publish": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Publish even when more parameters are removed than `max_parameter_removal` allows",
+			},
+			"max_changed_parameters": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Most parameters a publish may add, change or remove in the live template, checked at plan time. A publish changing more fails the plan " +
+					"with the list of parameters it would change, e.g. to protect against publishing the template file of another environment. Defaults to the provider `max_changed_parameters`",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"allow_large_publish": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Publish even when more parameters change than `max_changed_parameters` allows, with a warning listing them. Meant to be set from a variable for the one apply that needs it",
+			},
+			"manage_mode": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "How the declared template is published: `replace` (default) replaces every parameter, group and condition of the live template, " +
+					"`merge` publishes the declared ones over the live template and leaves the others, e.g. edited in the console, untouched. " +
+					"In merge mode declared conditions are evaluated before the others",
+				Validators: []validator.String{
+					stringvalidator.OneOf(manageModeReplace, manageModeMerge),
+				},
+			},
+			"canary": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Publish changed default values to a percentage of users first. A condition matching the canary cohort is generated and evaluated first, " +
+					"changed default values are served to it as conditional values while everyone else keeps the previous default. Set `promote` to serve them to everyone",
+				Attributes: map[string]schema.Attribute{
+					"condition": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Name of the generated condition, must not be declared in `conditions`",
+					},
+					"percent": schema.Int64Attribute{
+						Required:            true,
+						MarkdownDescription: "Percentage of users in the canary cohort",
+						Validators: []validator.Int64{
+							int64validator.Between(1, 100),
 						},
 					},
+					"promote": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Serve the new default values to everyone and remove the canary condition",
+					},
 				},
 			},
+			"ignore_parameters": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Parameters the provider neither diffs nor overwrites, wherever they live. They are published as they are live",
+			},
+			"ignore_parameter_groups": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Parameter groups, with all their members, the provider neither diffs nor overwrites. They are published as they are live",
+			},
+			"unmanaged_key_prefixes": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Key prefixes of the parameters left to the console, e.g. `ops_`. Like `ignore_parameters`, matching parameters are neither read, diffed nor overwritten",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"notify": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Webhook that receives a summary (project, version, changed keys, actor) after every successful publish",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "URL the summary is POSTed to",
+					},
+					"headers": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						Sensitive:           true,
+						MarkdownDescription: "Extra request headers, e.g. an Authorization header",
+					},
+					"payload_template": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Go template rendering the request body from `.Project`, `.Version`, `.ChangedKeys` and `.Actor`, with `join` and `json` helpers. Defaults to the summary as JSON",
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
-		// end parameter group
+	}
+}
 
+func (r *RemoteConfigResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		conditionReferencesValidator{},
+		duplicateParametersValidator{},
+		jsonValuesValidator{},
+		ignoredKeysValidator{},
+		renamedFromValidator{},
+		encryptedValuesValidator{},
+		templateLimitsValidator{},
+		resourcevalidator.Conflicting(path.MatchRoot("template_json"), path.MatchRoot("parameters")),
+		resourcevalidator.Conflicting(path.MatchRoot("template_json"), path.MatchRoot("conditions")),
+		resourcevalidator.Conflicting(path.MatchRoot("template_json"), path.MatchRoot("parameter_groups")),
+		resourcevalidator.Conflicting(path.MatchRoot("template_json"), path.MatchRoot("manage_mode")),
+		resourcevalidator.Conflicting(path.MatchRoot("template_json"), path.MatchRoot("canary")),
+		resourcevalidator.Conflicting(path.MatchRoot("template_json"), path.MatchRoot("ignore_parameters")),
+		resourcevalidator.Conflicting(path.MatchRoot("template_json"), path.MatchRoot("ignore_parameter_groups")),
+		resourcevalidator.Conflicting(path.MatchRoot("template_json"), path.MatchRoot("unmanaged_key_prefixes")),
 	}
 }
 
@@ -172,6 +491,7 @@ func (r *RemoteConfigResource) Configure(ctx context.Context, req resource.Confi
 	}
 
 	r.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_remoteconfig", remoteConfigAPIScopes)
 }
 
 func (r *RemoteConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -183,68 +503,124 @@ func (r *RemoteConfigResource) Create(ctx context.Context, req resource.CreateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withEncryptedParameters(ctx, data)
 
-	payload := RemoteConfigUpdate{
-		Parameters:      make(map[string]RemoteConfigParameter),
-		ParameterGroups: make(map[string]RemoteConfigParameterGroup),
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRemoteConfigTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	for _, item := range data.Parameters {
-		payload.Parameters[item.Name.ValueString()] = RemoteConfigParameter{
-			DefaultValue: ConfigValue{
-				Value: item.DefaultValue.ValueString(),
-			},
-			Description: item.Description.ValueString(),
-			ValueType:   item.ValueType.ValueString(),
-		}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if !r.client.checkWritable(&resp.Diagnostics, "publish the remote config template") {
+		return
 	}
 
-	for name, item := range data.ParameterGroups {
-		group := RemoteConfigParameterGroup{
-			Description: item.Description.ValueString(),
-			Parameters:  make(map[string]RemoteConfigParameter),
-		}
+	payload, diags := buildRemoteConfigUpdate(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		for pname, param := range item.Parameters {
-			group.Parameters[pname] = RemoteConfigParameter{
-				DefaultValue: ConfigValue{
-					Value: param.DefaultValue.ValueString(),
-				},
-				Description: param.Description.ValueString(),
-				ValueType:   param.ValueType.ValueString(),
-			}
+	// When overwriting, we force etag to always match
+	// Read more here: https://firebase.google.com/docs/reference/remote-config/rest/v1/projects/updateRemoteConfig
+	// This mean that when creating all data is lost and an operator should import existing state instead
+	data.Etag = types.StringValue("*")
+	toPublish := payload
+	var live *firebaseclient.RemoteConfigRead
+	if onCreate := data.OnCreate.ValueString(); onCreate == onCreateFailIfExists || onCreate == onCreateAdopt {
+		var etag string
+		var err error
+		live, etag, err = r.client.GetRemoteConfig(ctx, data.template(), "")
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read remote config of project %s: %s", data.Project.ValueString(), err))
+			return
+		}
+		if onCreate == onCreateFailIfExists && !templateEmpty(live) {
+			resp.Diagnostics.AddError(
+				"Remote Config Template Exists",
+				fmt.Sprintf("Project %s already has a template at version %s, last published by %s. "+
+					"Import it with terraform import, or set on_create to overwrite or adopt to publish over it.",
+					data.Project.ValueString(), live.Version.VersionNumber, live.Version.UpdateUser.Email),
+			)
+			return
+		}
+		// Publishing over the etag just read fails instead of overwriting a
+		// template published in the meantime.
+		data.Etag = types.StringValue(etag)
+		if onCreate == onCreateAdopt {
+			// What the configuration leaves unmanaged is adopted as it is live.
+			toPublish.Preserve = live.Raw
 		}
-		payload.ParameterGroups[name] = group
 	}
 
-	jsonData, err := json.Marshal(payload)
+	published, err := r.preparePublish(ctx, data, nil, toPublish, nil)
 	if err != nil {
-		tflog.Warn(ctx, fmt.Sprintf("Error encoding JSON: %v\n", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to read live template from firebase: %s", err))
+		return
+	}
+	// The guards below compare with a live template read as it is, so they
+	// count what is published with the plaintexts.
+	if published, err = r.client.decryptValues(ctx, published, data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to decrypt the template of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+	if published, err = readValueFiles(published, valueFiles(data)); err != nil {
+		resp.Diagnostics.AddError("Default Value File Changed", fmt.Sprintf("Unable to publish the template of project %s: %s", data.Project.ValueString(), err))
 		return
 	}
 
-	//httpReq, err := http.NewRequest("POST", fmt.Sprintf("https://firebaseremoteconfig.googleapis.com/v1/projects/%s/remoteConfig", data.project))
-	url := fmt.Sprintf("%s/v1/projects/%s/remoteConfig", r.client.endpoint, data.Project.ValueString())
-
-	tflog.Trace(ctx, fmt.Sprintf("submit %s %s", url, string(jsonData)))
-
-	// When creating, we force etag to always match
-	// Read more here: https://firebase.google.com/docs/reference/remote-config/rest/v1/projects/updateRemoteConfig
-	// This mean that when creating all data is lost and an operator should import existing state instead
-	data.Etag = types.StringValue("*")
+	if data.OnCreate.ValueString() == onCreateAdopt && sameTemplate(published, live) {
+		tflog.Info(ctx, fmt.Sprintf("adopt remote config of project %s at version %s without publishing", data.Project.ValueString(), live.Version.VersionNumber))
+		if err := r.client.redactLiveValues(ctx, data, live, true); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to adopt remote config of project %s: %s", data.Project.ValueString(), err))
+			return
+		}
+		data.ID = data.templateID()
+		data.Version = types.StringValue(live.Version.VersionNumber)
+		data.setVersionMetadata(live.Version)
+		data.setFencingToken()
+		data.TemplateSizeBytes = types.Int64Value(int64(len(live.Raw)))
+		data.LastPublishDurationMs = types.Int64Null()
+		data.RenderedTemplateJSON = renderedTemplateJSON(live.Raw)
+		data.TemplateHash = renderedTemplateHash(data.RenderedTemplateJSON)
+		data.RawResponseJSON = rawResponseJSON(data.ExposeRawResponse, live.Raw)
+		resp.Diagnostics.Append(data.setChangedParameters(ctx, []string{})...)
+		inputsHash, _ := r.client.publishInputsHash(ctx, data, payload)
+		resp.Diagnostics.Append(recordPublish(ctx, resp.Private, payload, inputsHash, data.Version.ValueString(), []string{})...)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, liveTemplateKey, live.Raw)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 
-	if err = r.writeToFireBase(ctx, url, payload, data); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to write data to firebase: %w", err))
+	// The guards run again here, plans with unknown values skip them.
+	var private privateStateGetter
+	if live != nil {
+		private = livePrivateState(live.Raw)
+	}
+	if !r.checkRemovals(ctx, data, published, private, &resp.Diagnostics) || !r.checkChanges(ctx, data, published, private, &resp.Diagnostics) {
 		return
 	}
 
-	slices.SortFunc(data.Parameters, func(a, b RemoteConfigParameterModel) int {
-		return strings.Compare(strings.ToLower(a.Name.ValueString()), strings.ToLower(b.Name.ValueString()))
-	})
+	target, err := r.writeToFireBase(ctx, published, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to write data to firebase: %s", err))
+		r.client.publishes.fail(data.Project.ValueString(), err)
+		if summary, ok := r.client.publishes.summary(); ok {
+			resp.Diagnostics.AddWarning("Partial Apply", summary)
+		}
+		return
+	}
+	r.client.publishes.succeeded(data.Project.ValueString(), data.Version.ValueString())
+	changed := changedParameterKeys(firebaseclient.RemoteConfigUpdate{}, payload)
+	resp.Diagnostics.Append(data.setChangedParameters(ctx, changed)...)
+	inputsHash, _ := r.client.publishInputsHash(ctx, data, payload)
+	resp.Diagnostics.Append(recordPublish(ctx, resp.Private, payload, inputsHash, data.Version.ValueString(), changed)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, liveTemplateKey, target.Raw)...)
+	r.notify(ctx, data, changed, target.Version, &resp.Diagnostics)
 
 	// By this time etag and version should be filled
-	//data.Version = types.StringValue(target.Version.VersionNumber)
-	//tflog.Trace(ctx, fmt.Sprintf("dumpo header %w", httpResp.Header))
-	//data.Etag = types.StringValue(httpResp.Header.Get("ETag"))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -257,86 +633,113 @@ func (r *RemoteConfigResource) Read(ctx context.Context, req resource.ReadReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withEncryptedParameters(ctx, &data)
 
-	projectID := data.Project.ValueString()
-	if projectID == "" {
-		// This is when we import the state
-		projectID = data.ID.ValueString()
-		data.Project = types.StringValue(projectID)
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRemoteConfigTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	url := fmt.Sprintf("%s/v1/projects/%s/remoteConfig", r.client.endpoint, projectID)
+	importing := data.Project.IsNull() || data.Project.ValueString() == ""
+	if importing {
+		// This is when we import the state
+		project, namespace, err := parseRemoteConfigImportID(data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+		data.Project = types.StringValue(project)
+		data.Namespace = types.StringNull()
+		if namespace != "" {
+			data.Namespace = types.StringValue(namespace)
+		}
+	}
+	projectID := data.Project.ValueString()
 
-	tflog.Trace(ctx, fmt.Sprintf("refresh resource data from %s", url))
 	tflog.Trace(ctx, fmt.Sprintf("dump data %v", data))
-	httpReq, err := http.NewRequest("GET", url, nil)
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+getAccessToken(r.client.accesstoken))
-	httpResp, err := r.client.Do(httpReq)
+	target, etag, err := r.client.GetRemoteConfig(ctx, data.template(), "")
 	if err != nil {
-		resp.Diagnostics.AddError("refresh error", fmt.Sprintf("unable to make http request to update config to firebase: %w", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read remote config of project %s: %s", projectID, err))
 		return
 	}
 
-	defer httpResp.Body.Close()
-	bodyBytes, err := io.ReadAll(httpResp.Body)
-
-	tflog.Trace(ctx, fmt.Sprintf("firebase api response %s %s", url, string(bodyBytes)))
-	tflog.Trace(ctx, fmt.Sprintf("firebase api header %w", httpResp.Header))
-
-	var target RemoteConfigRead
-	//err = json.NewDecoder(httpResp.Body).Decode(&target)
-	err = json.Unmarshal(bodyBytes, &target)
-
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create remote config on url: %s access token: %s \n%s, resp: %s", url, r.client.accesstoken, err, string(bodyBytes)))
+	// Encrypted values are only decrypted and compared with the live ones
+	// when the template was published since the provider last did.
+	lastPublished, diags := getLastPublish(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	verify := !r.client.ReadOnly() && (lastPublished == nil || lastPublished.Version != target.Version.VersionNumber)
+	if err := r.client.redactLiveValues(ctx, &data, target, verify); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to refresh remote config of project %s: %s", projectID, err))
 		return
 	}
 
-	data.Version = types.StringValue(target.Version.VersionNumber)
-	if httpResp.Header.Get("Etag") == "" {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("etag header is  missing in the response: %s %s", string(bodyBytes), httpResp.Header))
-		return
+	if !importing {
+		unfoldCanary(&data, target)
 	}
-
-	data.Parameters = []RemoteConfigParameterModel{}
-	for k, v := range target.Parameters {
-		data.Parameters = append(data.Parameters, RemoteConfigParameterModel{
-			Name:         types.StringValue(k),
-			Description:  types.StringValue(v.Description),
-			ValueType:    types.StringValue(v.ValueType),
-			DefaultValue: types.StringValue(v.DefaultValue.Value),
-		})
+	if err := r.refreshTemplate(ctx, &data, target, importing); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to refresh template_json of project %s: %s", projectID, err))
+		return
 	}
-	slices.SortFunc(data.Parameters, func(a, b RemoteConfigParameterModel) int {
-		return strings.Compare(strings.ToLower(a.Name.ValueString()), strings.ToLower(b.Name.ValueString()))
-	})
 
-	data.ParameterGroups = make(map[string]RemoteConfigParameterGroupModel)
-	for k, v := range target.ParameterGroups {
-		data.ParameterGroups[k] = RemoteConfigParameterGroupModel{
-			Description: types.StringValue(v.Description),
-			Parameters:  make(map[string]RemoteConfigParameterModel),
+	// A version description rendered from the provider template can't be
+	// decoded, the labels and annotations of the prior state are kept.
+	if metadata, ok := decodeVersionDescription(target.Version.Description); ok || r.client.versionDescriptionTemplate == nil {
+		priorLabels, diags := stringMapFromValue(ctx, data.Labels)
+		resp.Diagnostics.Append(diags...)
+		labels, diags := stringMapValue(ctx, r.client.withoutDefaultLabels(metadata.Labels, priorLabels))
+		resp.Diagnostics.Append(diags...)
+		annotations, diags := stringMapValue(ctx, metadata.Annotations)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		data.Labels = labels
+		data.Annotations = annotations
+	}
 
-		for paramName, paramValue := range v.Parameters {
-			data.ParameterGroups[k].Parameters[paramName] = RemoteConfigParameterModel{
-				Name:         types.StringValue(paramName),
-				Description:  types.StringValue(paramValue.Description),
-				ValueType:    types.StringValue(paramValue.ValueType),
-				DefaultValue: types.StringValue(paramValue.DefaultValue.Value),
+	data.ID = data.templateID()
+	data.Version = types.StringValue(target.Version.VersionNumber)
+	data.setVersionMetadata(target.Version)
+	data.Etag = types.StringValue(etag)
+	data.setFencingToken()
+	data.TemplateSizeBytes = types.Int64Value(int64(len(target.Raw)))
+	data.RenderedTemplateJSON = renderedTemplateJSON(target.Raw)
+	data.TemplateHash = renderedTemplateHash(data.RenderedTemplateJSON)
+	data.RawResponseJSON = rawResponseJSON(data.ExposeRawResponse, target.Raw)
+	if importing {
+		data.LastPublishDurationMs = types.Int64Null()
+		data.ChangedParameters = types.ListNull(types.StringType)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("refresh remote config for version %s etag %s", data.Version.ValueString(), data.Etag.ValueString()))
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, liveTemplateKey, target.Raw)...)
+
+	// Name who published out of band when the remote template no longer
+	// matches what the last apply published.
+	if lastPublished != nil && lastPublished.Version != target.Version.VersionNumber {
+		refreshed, _ := buildRemoteConfigUpdate(ctx, &data)
+		if hash, err := templateHash(refreshed); err == nil && hash != lastPublished.TemplateHash {
+			if data.AuditDrift.ValueBool() {
+				versions, err := r.client.ListVersionsSince(ctx, data.template(), lastPublished.Version, target.Version.VersionNumber)
+				if err != nil {
+					resp.Diagnostics.AddWarning("Remote Config Drift", fmt.Sprintf("%s\nUnable to list the versions published since: %s", describeDrift(projectID, lastPublished, target.Version), err))
+				} else {
+					resp.Diagnostics.AddWarning("Remote Config Drift", describeVersionChain(projectID, lastPublished, versions))
+				}
+			} else {
+				resp.Diagnostics.AddWarning("Remote Config Drift", describeDrift(projectID, lastPublished, target.Version))
 			}
 		}
 	}
-
-	slices.SortFunc(data.Parameters, func(a, b RemoteConfigParameterModel) int {
-		return strings.Compare(strings.ToLower(a.Name.ValueString()), strings.ToLower(b.Name.ValueString()))
-	})
-
-	data.ID = types.StringValue(data.Project.ValueString())
-	data.Version = types.StringValue(target.Version.VersionNumber)
-	data.Etag = types.StringValue(httpResp.Header.Get("ETag"))
-	tflog.Trace(ctx, fmt.Sprintf("refresh remote config for version %s", data.Version.ValueString(), data.Etag.ValueString()))
+	// States written before changed_parameters existed get it back from the
+	// publish record.
+	if lastPublished != nil && lastPublished.Version == target.Version.VersionNumber &&
+		data.ChangedParameters.IsNull() && lastPublished.ChangedParameters != nil {
+		resp.Diagnostics.Append(data.setChangedParameters(ctx, lastPublished.ChangedParameters)...)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -355,39 +758,22 @@ func (r *RemoteConfigResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	payload := RemoteConfigUpdate{
-		Parameters:      make(map[string]RemoteConfigParameter),
-		ParameterGroups: make(map[string]RemoteConfigParameterGroup),
-	}
-	for _, item := range data.Parameters {
-		payload.Parameters[item.Name.ValueString()] = RemoteConfigParameter{
-			DefaultValue: ConfigValue{
-				Value: item.DefaultValue.ValueString(),
-			},
-			Description: item.Description.ValueString(),
-			ValueType:   item.ValueType.ValueString(),
-		}
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultRemoteConfigTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	slices.SortFunc(data.Parameters, func(a, b RemoteConfigParameterModel) int {
-		return strings.Compare(strings.ToLower(a.Name.ValueString()), strings.ToLower(b.Name.ValueString()))
-	})
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	for name, item := range data.ParameterGroups {
-		group := RemoteConfigParameterGroup{
-			Description: item.Description.ValueString(),
-			Parameters:  make(map[string]RemoteConfigParameter),
-		}
+	if !r.client.checkWritable(&resp.Diagnostics, "publish the remote config template") {
+		return
+	}
 
-		for pname, param := range item.Parameters {
-			group.Parameters[pname] = RemoteConfigParameter{
-				DefaultValue: ConfigValue{
-					Value: param.DefaultValue.ValueString(),
-				},
-				Description: param.Description.ValueString(),
-				ValueType:   param.ValueType.ValueString(),
-			}
-		}
-		payload.ParameterGroups[name] = group
+	payload, diags := buildRemoteConfigUpdate(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	var state RemoteConfigResourceModel
@@ -396,16 +782,88 @@ func (r *RemoteConfigResource) Update(ctx context.Context, req resource.UpdateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withEncryptedParameters(ctx, &data, &state)
 
 	data.Etag = types.StringValue(state.Etag.ValueString())
+	if data.ForcePublish.ValueBool() {
+		data.Etag = types.StringValue("*")
+	}
+
+	// Changes to attributes that don't end up in the template must not
+	// produce a new Remote Config version.
+	lastPublished, diags := getLastPublish(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if r.client.publishedUnchanged(ctx, lastPublished, &data, &state, payload) {
+		tflog.Debug(ctx, fmt.Sprintf("template unchanged since version %s, skip publish", lastPublished.Version))
+		data.ID = state.ID
+		data.Version = state.Version
+		data.UpdateTime = state.UpdateTime
+		data.UpdateUser = state.UpdateUser
+		data.UpdateOrigin = state.UpdateOrigin
+		data.UpdateType = state.UpdateType
+		data.FencingToken = state.FencingToken
+		data.TemplateSizeBytes = state.TemplateSizeBytes
+		data.LastPublishDurationMs = state.LastPublishDurationMs
+		data.ChangedParameters = state.ChangedParameters
+		data.RenderedTemplateJSON = state.RenderedTemplateJSON
+		data.TemplateHash = state.TemplateHash
+		data.RawResponseJSON = state.RawResponseJSON
+		if !data.ExposeRawResponse.ValueBool() {
+			data.RawResponseJSON = types.StringNull()
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	published, err := r.preparePublish(ctx, &data, &state, payload, req.Private)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to read live template from firebase: %s", err))
+		return
+	}
 
-	//httpReq, err := http.NewRequest("POST", fmt.Sprintf("https://firebaseremoteconfig.googleapis.com/v1/projects/%s/remoteConfig", data.project))
-	url := fmt.Sprintf("%s/v1/projects/%s/remoteConfig", r.client.endpoint, data.Project.ValueString())
+	if !r.checkRemovals(ctx, &data, published, req.Private, &resp.Diagnostics) || !r.checkChanges(ctx, &data, published, req.Private, &resp.Diagnostics) {
+		return
+	}
 
-	if err := r.writeToFireBase(ctx, url, payload, &data); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to write data to firebase: %w", err))
+	// Last, so the plaintexts and the contents of files are only ever in
+	// what is sent to the API.
+	if published, err = r.client.decryptValues(ctx, published, &data, &state); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to decrypt the template of project %s: %s", data.Project.ValueString(), err))
 		return
 	}
+	if published, err = readValueFiles(published, valueFiles(&data, &state)); err != nil {
+		resp.Diagnostics.AddError("Default Value File Changed", fmt.Sprintf("Unable to publish the template of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+	target, err := r.writeToFireBase(ctx, published, &data)
+	if err != nil {
+		target, err = r.retryConflict(ctx, &data, &state, payload, req.Private, err)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to write data to firebase: %s", err))
+		r.client.publishes.fail(data.Project.ValueString(), err)
+		if summary, ok := r.client.publishes.summary(); ok {
+			resp.Diagnostics.AddWarning("Partial Apply", summary)
+		}
+		return
+	}
+	if data.ForcePublish.ValueBool() {
+		if overridden := overriddenVersions(state.Version.ValueString(), data.Version.ValueString()); overridden != "" {
+			resp.Diagnostics.AddWarning(
+				"Remote Config Changes Overridden",
+				fmt.Sprintf("force_publish published version %s of project %s over %s, published since the last refresh. Their changes are discarded.", data.Version.ValueString(), data.Project.ValueString(), overridden),
+			)
+		}
+	}
+	r.client.publishes.succeeded(data.Project.ValueString(), data.Version.ValueString())
+	previous, _ := buildRemoteConfigUpdate(ctx, &state)
+	changed := changedParameterKeys(previous, payload)
+	resp.Diagnostics.Append(data.setChangedParameters(ctx, changed)...)
+	inputsHash, _ := r.client.publishInputsHash(ctx, &data, payload)
+	resp.Diagnostics.Append(recordPublish(ctx, resp.Private, payload, inputsHash, data.Version.ValueString(), changed)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, liveTemplateKey, target.Raw)...)
+
+	r.notify(ctx, &data, changed, target.Version, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -419,112 +877,254 @@ func (r *RemoteConfigResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete example, got error: %s", err))
-	//     return
-	// }
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRemoteConfigTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if !r.client.checkWritable(&resp.Diagnostics, "delete the remote config resource") {
+		return
+	}
+
+	if data.OnDestroy.ValueString() != onDestroyClear {
+		tflog.Info(ctx, fmt.Sprintf("abandon remote config of project %s at version %s", data.Project.ValueString(), data.Version.ValueString()))
+		return
+	}
+
+	// Publishing over the stored etag fails when the template changed since
+	// the last refresh instead of clearing changes made out of band.
+	// In merge mode only what the resource declared is cleared, ignored
+	// parameters and groups are always kept.
+	empty := firebaseclient.RemoteConfigUpdate{
+		Conditions:      []firebaseclient.RemoteConfigCondition{},
+		Parameters:      map[string]firebaseclient.RemoteConfigParameter{},
+		ParameterGroups: map[string]firebaseclient.RemoteConfigParameterGroup{},
+	}
+	empty, err := r.mergeWithRemote(ctx, &data, &data, empty)
+	if err == nil {
+		empty, err = r.keepIgnored(ctx, &data, empty, req.Private)
+	}
+	if err == nil {
+		_, err = r.writeToFireBase(ctx, empty, &data)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to clear remote config of project %s: %s", data.Project.ValueString(), err))
+		r.client.publishes.fail(data.Project.ValueString(), err)
+		return
+	}
+	r.client.publishes.succeeded(data.Project.ValueString(), data.Version.ValueString())
 }
 
 func (r *RemoteConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func (r *RemoteConfigResource) writeToFireBase(ctx context.Context, url string, payload RemoteConfigUpdate, data *RemoteConfigResourceModel) error {
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		tflog.Warn(ctx, fmt.Sprintf("Error encoding JSON: %v\n", err))
-		return err
+// notify posts the publish summary to the notify webhook when configured.
+// The template is already published, so failures are only warnings.
+func (r *RemoteConfigResource) notify(ctx context.Context, data *RemoteConfigResourceModel, changed []string, version firebaseclient.RemoteConfigVersion, diags *diag.Diagnostics) {
+	if data.Notify == nil {
+		return
 	}
 
-	httpReq, err := http.NewRequest("PUT", url, bytes.NewReader(jsonData))
-	httpReq.Header.Set("Content-Type", "application/json")
-	tflog.Trace(ctx, fmt.Sprintf("dump data %v", data))
-	tflog.Trace(ctx, fmt.Sprintf("prepare to update remote config url: %s etag: %s version %s payload: %s", url, data.Etag.ValueString(), data.Version.ValueString(), string(jsonData)))
-	httpReq.Header.Set("If-Match", data.Etag.ValueString())
+	summary := publishSummary{
+		Project:     data.Project.ValueString(),
+		Version:     data.Version.ValueString(),
+		ChangedKeys: changed,
+		Actor:       version.UpdateUser.Email,
+	}
+	if err := r.client.notifyPublish(ctx, data.Notify, summary); err != nil {
+		diags.AddWarning("Notify Error", fmt.Sprintf("Version %s was published but the notify webhook failed: %s", summary.Version, err))
+	}
+}
 
-	httpReq.Header.Set("Authorization", "Bearer "+getAccessToken(r.client.accesstoken))
-	httpResp, err := r.client.Do(httpReq)
+// preparePublish turns the declared payload into the template to publish,
+// completing it with the parts of the live template the resource doesn't
+// manage. A template_json is published as it is. Encrypted values are left
+// as placeholders, see decryptValues.
+func (r *RemoteConfigResource) preparePublish(ctx context.Context, data, prior *RemoteConfigResourceModel, payload firebaseclient.RemoteConfigUpdate, private privateStateGetter) (firebaseclient.RemoteConfigUpdate, error) {
+	payload, err := r.client.applyVersionDefaults(ctx, data, payload)
 	if err != nil {
-		return fmt.Errorf("unable to make http request to update config to firebase: %w", err)
+		return payload, err
+	}
+	if !data.TemplateJSON.IsNull() {
+		// The template is published verbatim.
+		return payload, nil
 	}
 
-	defer httpResp.Body.Close()
-	bodyBytes, err := io.ReadAll(httpResp.Body)
+	published, err := r.completeFromRemote(ctx, data, payload, private)
+	if err != nil {
+		return published, err
+	}
+	if published, err = r.mergeWithRemote(ctx, data, prior, published); err != nil {
+		return published, err
+	}
 
-	tflog.Trace(ctx, fmt.Sprintf("firebase api response %s %s", url, string(bodyBytes)))
-	tflog.Trace(ctx, fmt.Sprintf("firebase api header %w", httpResp.Header))
+	if published, err = r.applyRenames(ctx, data, published, private); err != nil {
+		return published, err
+	}
+
+	if published, err = r.keepIgnored(ctx, data, published, private); err != nil {
+		return published, err
+	}
+
+	return r.applyCanary(ctx, data, published, private)
+}
 
-	var target RemoteConfigRead
-	//err = json.NewDecoder(httpResp.Body).Decode(&target)
-	err = json.Unmarshal(bodyBytes, &target)
+// liveTemplate returns the live template kept in private state by the last
+// refresh, so the etag guard still applies, or a fresh one when there is
+// none.
+func (r *RemoteConfigResource) liveTemplate(ctx context.Context, data *RemoteConfigResourceModel, private privateStateGetter) (*firebaseclient.RemoteConfigRead, error) {
+	if private != nil {
+		if raw, diags := private.GetKey(ctx, liveTemplateKey); !diags.HasError() && len(raw) > 0 {
+			var live firebaseclient.RemoteConfigRead
+			if err := json.Unmarshal(raw, &live); err == nil {
+				live.Raw = raw
+				return &live, nil
+			}
+		}
+	}
+
+	live, _, err := r.client.GetRemoteConfig(ctx, data.template(), "")
+	return live, err
+}
+
+// completeFromRemote fills the parts of the payload that are not managed by
+// the resource from the live template, either the one kept in private state
+// by the last refresh or a fresh one when needed. The payload itself is left
+// untouched so it can still be compared with the configuration.
+func (r *RemoteConfigResource) completeFromRemote(ctx context.Context, data *RemoteConfigResourceModel, payload firebaseclient.RemoteConfigUpdate, private privateStateGetter) (firebaseclient.RemoteConfigUpdate, error) {
+	if private != nil {
+		live, diags := private.GetKey(ctx, liveTemplateKey)
+		if !diags.HasError() {
+			payload.Preserve = live
+		}
+	}
 
+	var descriptionOnly []string
+	for name, group := range payload.ParameterGroups {
+		if len(group.Parameters) == 0 {
+			descriptionOnly = append(descriptionOnly, name)
+		}
+	}
+	if len(descriptionOnly) == 0 {
+		return payload, nil
+	}
+
+	remote, _, err := r.client.GetRemoteConfig(ctx, data.template(), "")
 	if err != nil {
-		return fmt.Errorf("Unable to create remote config on url: %s access token: %s \n%s, resp: %s", url, r.client.accesstoken, err, string(bodyBytes))
+		return payload, err
 	}
+	payload.Preserve = remote.Raw
 
-	if httpResp.Header.Get("Etag") == "" {
-		return fmt.Errorf("cannot write to firebase:\n%s", string(bodyBytes))
+	groups := make(map[string]firebaseclient.RemoteConfigParameterGroup, len(payload.ParameterGroups))
+	for name, group := range payload.ParameterGroups {
+		groups[name] = group
 	}
+	for _, name := range descriptionOnly {
+		group := groups[name]
+		if members := remote.ParameterGroups[name].Parameters; members != nil {
+			group.Parameters = members
+		}
+		tflog.Debug(ctx, fmt.Sprintf("keep %d live members of description only group %s", len(group.Parameters), name))
+		groups[name] = group
+	}
+	payload.ParameterGroups = groups
+
+	return payload, nil
+}
+
+func (r *RemoteConfigResource) writeToFireBase(ctx context.Context, payload firebaseclient.RemoteConfigUpdate, data *RemoteConfigResourceModel) (*firebaseclient.RemoteConfigRead, error) {
+	tflog.Trace(ctx, fmt.Sprintf("dump data %v", data))
+
+	if r.client.descriptionMarkdown == descriptionMarkdownStrip {
+		payload = stripPayloadDescriptions(payload)
+	}
+
+	start := time.Now()
+	target, etag, err := r.client.PublishRemoteConfig(ctx, data.template(), data.Etag.ValueString(), payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.client.redactLiveValues(ctx, data, target, true); err != nil {
+		return nil, err
+	}
+	data.LastPublishDurationMs = types.Int64Value(time.Since(start).Milliseconds())
+	data.TemplateSizeBytes = types.Int64Value(int64(len(target.Raw)))
+	data.RenderedTemplateJSON = renderedTemplateJSON(target.Raw)
+	data.TemplateHash = renderedTemplateHash(data.RenderedTemplateJSON)
+	data.RawResponseJSON = rawResponseJSON(data.ExposeRawResponse, target.Raw)
 
 	data.Version = types.StringValue(target.Version.VersionNumber)
-	data.Etag = types.StringValue(httpResp.Header.Get("ETag"))
-	data.ID = types.StringValue(data.Project.ValueString())
+	data.setVersionMetadata(target.Version)
+	data.Etag = types.StringValue(etag)
+	data.setFencingToken()
+	data.ID = data.templateID()
 
-	tflog.Trace(ctx, fmt.Sprintf("publish remote config with version %s and etag %s", data.Version, data.Etag))
+	tflog.Trace(ctx, fmt.Sprintf("publish remote config with version %s and etag %s", data.Version.ValueString(), data.Etag.ValueString()))
 
-	return nil
+	return target, nil
 }
 
-type ConfigValue struct {
-	Value string `json:"value"`
-}
-type RemoteConfigParameter struct {
-	DefaultValue ConfigValue `json:"defaultValue"`
-	Description  string      `json:"description"`
-	ValueType    string      `json:"valueType"`
-}
+// refreshTemplate refreshes the template attributes of the model from a
+// live template, keeping the parts the prior model leaves unmanaged.
+func (r *RemoteConfigResource) refreshTemplate(ctx context.Context, data *RemoteConfigResourceModel, target *firebaseclient.RemoteConfigRead, importing bool) error {
+	if !data.TemplateJSON.IsNull() {
+		return refreshTemplateJSON(data, target)
+	}
 
-type RemoteConfigParameterGroup struct {
-	Description string                           `json:"description"`
-	Parameters  map[string]RemoteConfigParameter `json:"parameters"`
-}
-type RemoteConfigVersion struct {
-	VersionNumber string    `json:"versionNumber"`
-	UpdateTime    time.Time `json:"updateTime"`
-	UpdateUser    struct {
-		Email string `json:"email"`
-	} `json:"updateUser"`
-	UpdateOrigin string `json:"updateOrigin"`
-	UpdateType   string `json:"updateType"`
-}
+	prior := *data
+	applyRemoteTemplate(data, target, importing)
+	if !importing {
+		dropIgnored(ctx, &prior, data)
+	}
+	if !importing && data.ManageMode.ValueString() == manageModeMerge {
+		keepManaged(&prior, data)
+	}
+	if r.client.descriptionMarkdown == descriptionMarkdownStrip && !importing {
+		keepStrippedDescriptions(&prior, data)
+	}
+	// Unset and empty are the same template, keep what was configured.
+	if prior.Parameters == nil && len(data.Parameters) == 0 {
+		data.Parameters = nil
+	}
+	if prior.ParameterGroups == nil && len(data.ParameterGroups) == 0 {
+		data.ParameterGroups = nil
+	}
 
-type RemoteConfigRead struct {
-	Parameters      map[string]RemoteConfigParameter      `json:"parameters"`
-	ParameterGroups map[string]RemoteConfigParameterGroup `json:"parameterGroups"`
-	Version         RemoteConfigVersion                   `json:"version"`
+	return nil
 }
 
-type RemoteConfigUpdate struct {
-	Parameters      map[string]RemoteConfigParameter      `json:"parameters"`
-	ParameterGroups map[string]RemoteConfigParameterGroup `json:"parameter_groups"`
-}
+// setChangedParameters sets changed_parameters to the keys changed by a
+// publish.
+func (m *RemoteConfigResourceModel) setChangedParameters(ctx context.Context, changed []string) diag.Diagnostics {
+	value, diags := types.ListValueFrom(ctx, types.StringType, changed)
+	m.ChangedParameters = value
 
-func getAccessToken(clientCreds string) string {
-	scopes := []string{"https://www.googleapis.com/auth/cloud-platform"} // Specify required scopes
+	return diags
+}
 
-	// Find default credentials using the environment variable or ADC
-	credentials, err := google.JWTConfigFromJSON([]byte(clientCreds), scopes...)
-	if err != nil {
-		panic(err)
+// setFencingToken sets fencing_token from the project, namespace, version
+// and etag of the model.
+func (m *RemoteConfigResourceModel) setFencingToken() {
+	namespace := m.Namespace.ValueString()
+	if namespace == "" {
+		namespace = firebaseclient.NamespaceFirebase
 	}
 
-	// Get the access token
-	token, err := credentials.TokenSource(context.Background()).Token()
-	if err != nil {
-		panic(err)
+	m.FencingToken = types.StringValue(fmt.Sprintf("%s/%s/%s/%s", m.Project.ValueString(), namespace, m.Version.ValueString(), m.Etag.ValueString()))
+}
+
+// setVersionMetadata sets the computed attributes describing the live
+// version.
+func (m *RemoteConfigResourceModel) setVersionMetadata(version firebaseclient.RemoteConfigVersion) {
+	m.UpdateTime = types.StringNull()
+	if !version.UpdateTime.IsZero() {
+		m.UpdateTime = types.StringValue(version.UpdateTime.Format(time.RFC3339Nano))
 	}
-	return token.AccessToken
+	m.UpdateUser = types.StringValue(version.UpdateUser.Email)
+	m.UpdateOrigin = types.StringValue(version.UpdateOrigin)
+	m.UpdateType = types.StringValue(version.UpdateType)
 }
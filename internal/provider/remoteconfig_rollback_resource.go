@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RemoteConfigRollbackResource{}
+
+func NewRemoteConfigRollbackResource() resource.Resource {
+	return &RemoteConfigRollbackResource{}
+}
+
+// RemoteConfigRollbackResource rolls a project back to a previous Remote
+// Config version when created. It behaves like an action: it never reads
+// the live template back and destroying it does nothing.
+type RemoteConfigRollbackResource struct {
+	client *FirebaseClient
+}
+
+// RemoteConfigRollbackResourceModel describes the resource data model.
+type RemoteConfigRollbackResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Project       types.String `tfsdk:"project"`
+	VersionNumber types.String `tfsdk:"version_number"`
+	Version       types.String `tfsdk:"version"`
+	Etag          types.String `tfsdk:"etag"`
+}
+
+func (r *RemoteConfigRollbackResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remoteconfig_rollback"
+}
+
+func (r *RemoteConfigRollbackResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Rolls the Remote Config template of a project back to a known good version when created, publishing a copy of it as a new version. " +
+			"Changing `version_number` rolls back again, destroying the resource leaves the template as is. " +
+			"A `firebaseextra_remoteconfig` managing the same project reports the rollback as drift on its next refresh",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project and version the template was rolled back to",
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version_number": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Version to roll back to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					versionNumberValidator{},
+				},
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Version published by the rollback",
+			},
+			"etag": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Etag of the template published by the rollback",
+			},
+		},
+	}
+}
+
+func (r *RemoteConfigRollbackResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_remoteconfig_rollback", remoteConfigAPIScopes)
+}
+
+func (r *RemoteConfigRollbackResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RemoteConfigRollbackResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "roll back the remote config template") {
+		return
+	}
+
+	target, etag, err := r.client.RollbackRemoteConfig(ctx, data.Project.ValueString(), data.VersionNumber.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to roll back remote config of project %s to version %s: %s", data.Project.ValueString(), data.VersionNumber.ValueString(), err))
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("rolled back remote config of project %s to version %s as version %s", data.Project.ValueString(), data.VersionNumber.ValueString(), target.Version.VersionNumber))
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), data.VersionNumber.ValueString()))
+	data.Version = types.StringValue(target.Version.VersionNumber)
+	data.Etag = types.StringValue(etag)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigRollbackResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The rollback happened once, there is nothing to refresh.
+}
+
+func (r *RemoteConfigRollbackResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every configurable attribute requires replacement.
+	var data RemoteConfigRollbackResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigRollbackResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Rolling back can't be undone, removing it from the state is enough.
+}
+
+// versionNumberValidator checks that a string is a Remote Config version
+// number.
+type versionNumberValidator struct{}
+
+func (v versionNumberValidator) Description(ctx context.Context) string {
+	return "value must be a positive version number"
+}
+
+func (v versionNumberValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v versionNumberValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if n, err := strconv.ParseInt(req.ConfigValue.ValueString(), 10, 64); err != nil || n < 1 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Version Number",
+			fmt.Sprintf("%q is not a Remote Config version number.", req.ConfigValue.ValueString()),
+		)
+	}
+}
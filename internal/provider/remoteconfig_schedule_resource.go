@@ -0,0 +1,406 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RemoteConfigScheduleResource{}
+var _ resource.ResourceWithModifyPlan = &RemoteConfigScheduleResource{}
+
+// Values of the status of a schedule.
+const (
+	scheduleStatusPending   = "pending"
+	scheduleStatusPublished = "published"
+	scheduleStatusExpired   = "expired"
+)
+
+func NewRemoteConfigScheduleResource() resource.Resource {
+	return &RemoteConfigScheduleResource{}
+}
+
+// RemoteConfigScheduleResource keeps a validated template pending until an
+// apply runs within its publish window.
+type RemoteConfigScheduleResource struct {
+	client *FirebaseClient
+}
+
+// RemoteConfigScheduleResourceModel describes the resource data model.
+type RemoteConfigScheduleResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Project          types.String `tfsdk:"project"`
+	TemplateJSON     types.String `tfsdk:"template_json"`
+	WindowStart      types.String `tfsdk:"window_start"`
+	WindowEnd        types.String `tfsdk:"window_end"`
+	Status           types.String `tfsdk:"status"`
+	PublishedVersion types.String `tfsdk:"published_version"`
+	BaseVersion      types.String `tfsdk:"base_version"`
+	ForcePublish     types.Bool   `tfsdk:"force_publish"`
+}
+
+// window parses the publish window of the model.
+func (m *RemoteConfigScheduleResourceModel) window() (start, end time.Time, err error) {
+	if start, err = time.Parse(time.RFC3339, m.WindowStart.ValueString()); err != nil {
+		return start, end, err
+	}
+	end, err = time.Parse(time.RFC3339, m.WindowEnd.ValueString())
+	return start, end, err
+}
+
+func (r *RemoteConfigScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remoteconfig_schedule"
+}
+
+func (r *RemoteConfigScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Stages a Remote Config template for a maintenance window. The template is validated when created and published by the first apply " +
+			"running between `window_start` and `window_end`, so change freezes can be honoured by scheduling applies. The template replaces the live one as a whole. " +
+			"When the window opens the template is validated again and only published when no other version was published since it was scheduled, unless `force_publish` is set",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project and publish window",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"template_json": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Template to publish, in the JSON format of the Remote Config REST API",
+			},
+			"window_start": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "RFC 3339 time from which the template can be published",
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"window_end": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "RFC 3339 time after which the template is no longer published",
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "`pending` until published, then `published`, or `expired` when the window passed without an apply",
+			},
+			"published_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Version the template was published as",
+			},
+			"base_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Version live when the template was scheduled. The scheduled publish fails when another version was published since, e.g. a hotfix in the console",
+			},
+			"force_publish": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Publish the scheduled template even when versions were published since it was scheduled, discarding their changes. " +
+					"A warning lists the versions that were overridden",
+			},
+		},
+	}
+}
+
+func (r *RemoteConfigScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_remoteconfig_schedule", remoteConfigAPIScopes)
+}
+
+func (r *RemoteConfigScheduleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var state, plan RemoteConfigScheduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changed := !plan.TemplateJSON.Equal(state.TemplateJSON) || !plan.WindowStart.Equal(state.WindowStart) || !plan.WindowEnd.Equal(state.WindowEnd)
+	if changed {
+		// A new template or window schedules a new publish.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("published_version"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("base_version"), types.StringUnknown())...)
+		return
+	}
+
+	// Nothing changed, keep the state unless the window is open.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), state.Status)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("published_version"), state.PublishedVersion)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("base_version"), state.BaseVersion)...)
+	if state.Status.ValueString() != scheduleStatusPending {
+		return
+	}
+
+	start, end, err := plan.window()
+	if err != nil {
+		return
+	}
+	if now := time.Now(); now.After(start) && now.Before(end) {
+		resp.Diagnostics.AddWarning("Scheduled Publish", fmt.Sprintf("The publish window of project %s is open, this apply publishes the scheduled template.", plan.Project.ValueString()))
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("published_version"), types.StringUnknown())...)
+	}
+}
+
+func (r *RemoteConfigScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RemoteConfigScheduleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), data.WindowStart.ValueString()))
+	data.Status = types.StringValue(scheduleStatusPending)
+	data.PublishedVersion = types.StringNull()
+	r.schedule(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RemoteConfigScheduleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, end, err := data.window(); err == nil && data.Status.ValueString() == scheduleStatusPending && time.Now().After(end) {
+		data.Status = types.StringValue(scheduleStatusExpired)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RemoteConfigScheduleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Project.ValueString(), data.WindowStart.ValueString()))
+	if data.Status.IsUnknown() && data.TemplateJSON.Equal(state.TemplateJSON) && data.WindowStart.Equal(state.WindowStart) && data.WindowEnd.Equal(state.WindowEnd) {
+		// Only the window opened, or force_publish changed.
+		data.Status = state.Status
+		data.PublishedVersion = state.PublishedVersion
+		data.BaseVersion = state.BaseVersion
+		r.publishIfOpen(ctx, &data, &resp.Diagnostics)
+	} else if data.Status.IsUnknown() {
+		data.Status = types.StringValue(scheduleStatusPending)
+		data.PublishedVersion = types.StringNull()
+		r.schedule(ctx, &data, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RemoteConfigScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A pending template only lives in the state, a published one stays
+	// published.
+}
+
+// schedule validates the template of the model, records the version it is
+// scheduled over and publishes it right away when its window is open.
+func (r *RemoteConfigScheduleResource) schedule(ctx context.Context, data *RemoteConfigScheduleResourceModel, diags *diag.Diagnostics) {
+	template, err := decodeTemplateJSON(data.TemplateJSON.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("template_json"), "Invalid Template", err.Error())
+		return
+	}
+
+	start, end, err := data.window()
+	if err != nil {
+		diags.AddError("Invalid Publish Window", err.Error())
+		return
+	}
+	if !end.After(start) {
+		diags.AddAttributeError(path.Root("window_end"), "Invalid Publish Window", "window_end must be after window_start.")
+		return
+	}
+
+	if r.client.ReadOnly() {
+		tflog.Debug(ctx, "read only provider, skip validation of the scheduled template")
+	} else if err := r.client.ValidateRemoteConfig(ctx, data.Project.ValueString(), template); err != nil {
+		summary := "Client Error"
+		if errors.Is(err, firebaseclient.ErrInvalidTemplate) {
+			summary = "Invalid Remote Config Template"
+		}
+		diags.AddError(summary, fmt.Sprintf("Unable to validate the scheduled template: %s", err))
+		return
+	}
+
+	live, _, err := r.client.GetRemoteConfig(ctx, data.Project.ValueString(), "")
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read remote config of project %s: %s", data.Project.ValueString(), err))
+		return
+	}
+	data.BaseVersion = types.StringValue(live.Version.VersionNumber)
+
+	r.publishIfOpen(ctx, data, diags)
+}
+
+// publishIfOpen publishes a pending template when its window is open. The
+// template may have been validated long before, so it is validated again
+// and published over the etag of the template just read, which must still
+// be the version it was scheduled over unless force_publish is set.
+func (r *RemoteConfigScheduleResource) publishIfOpen(ctx context.Context, data *RemoteConfigScheduleResourceModel, diags *diag.Diagnostics) {
+	start, end, err := data.window()
+	if err != nil || data.Status.ValueString() != scheduleStatusPending {
+		return
+	}
+	if now := time.Now(); now.Before(start) || now.After(end) {
+		tflog.Info(ctx, fmt.Sprintf("publish window of project %s is closed, keep the template pending", data.Project.ValueString()))
+		return
+	}
+
+	if !r.client.checkWritable(diags, "publish the scheduled remote config template") {
+		return
+	}
+
+	template, err := decodeTemplateJSON(data.TemplateJSON.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("template_json"), "Invalid Template", err.Error())
+		return
+	}
+
+	project := data.Project.ValueString()
+	live, etag, err := r.client.GetRemoteConfig(ctx, project, "")
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read remote config of project %s: %s", project, err))
+		return
+	}
+	base := data.BaseVersion.ValueString()
+	changed := base != "" && live.Version.VersionNumber != base
+	if changed && !data.ForcePublish.ValueBool() {
+		diags.AddError(
+			"Remote Config Changed Since Scheduled",
+			fmt.Sprintf("Version %s of project %s was published after the template was scheduled over version %s, last by %s. "+
+				"Check the changes are in the scheduled template, then set force_publish = true to publish it over them, or schedule it again.",
+				live.Version.VersionNumber, project, base, live.Version.UpdateUser.Email),
+		)
+		return
+	}
+
+	if err := r.client.ValidateRemoteConfig(ctx, project, template); err != nil {
+		summary := "Client Error"
+		if errors.Is(err, firebaseclient.ErrInvalidTemplate) {
+			summary = "Invalid Remote Config Template"
+		}
+		diags.AddError(summary, fmt.Sprintf("Unable to validate the scheduled template: %s", err))
+		return
+	}
+
+	target, _, err := r.client.PublishRemoteConfig(ctx, project, etag, template)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to publish the scheduled template of project %s: %s", project, err))
+		return
+	}
+	if overridden := overriddenVersions(base, target.Version.VersionNumber); changed && overridden != "" {
+		diags.AddWarning(
+			"Remote Config Changes Overridden",
+			fmt.Sprintf("force_publish published the scheduled template of project %s as version %s over %s, published since it was scheduled. Their changes are discarded.", project, target.Version.VersionNumber, overridden),
+		)
+	}
+
+	data.Status = types.StringValue(scheduleStatusPublished)
+	data.PublishedVersion = types.StringValue(target.Version.VersionNumber)
+}
+
+// decodeTemplateJSON parses a template in the REST API format. Fields the
+// client doesn't model are published as they are.
+func decodeTemplateJSON(templateJSON string) (firebaseclient.RemoteConfigUpdate, error) {
+	var template firebaseclient.RemoteConfigUpdate
+	if err := json.Unmarshal([]byte(templateJSON), &template); err != nil {
+		return template, fmt.Errorf("template_json is not a Remote Config template: %w", err)
+	}
+	template.Preserve = json.RawMessage(templateJSON)
+
+	return template, nil
+}
+
+// rfc3339Validator checks that a string is an RFC 3339 time.
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be an RFC 3339 time"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Time", fmt.Sprintf("%q is not an RFC 3339 time: %s", req.ConfigValue.ValueString(), err))
+	}
+}
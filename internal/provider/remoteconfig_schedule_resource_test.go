@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// openSchedule returns a pending schedule whose window is open.
+func openSchedule(project string) *RemoteConfigScheduleResourceModel {
+	now := time.Now()
+	return &RemoteConfigScheduleResourceModel{
+		Project:          types.StringValue(project),
+		TemplateJSON:     types.StringValue(`{"parameters": {"welcome": {"defaultValue": {"value": "scheduled"}, "valueType": "STRING"}}}`),
+		WindowStart:      types.StringValue(now.Add(-time.Hour).Format(time.RFC3339)),
+		WindowEnd:        types.StringValue(now.Add(time.Hour).Format(time.RFC3339)),
+		Status:           types.StringValue(scheduleStatusPending),
+		PublishedVersion: types.StringNull(),
+		BaseVersion:      types.StringValue("1"),
+		ForcePublish:     types.BoolNull(),
+	}
+}
+
+// publishHotfix publishes a template to the fake, as a console user would.
+func publishHotfix(t *testing.T, client *FirebaseClient, project string) {
+	t.Helper()
+
+	hotfix := firebaseclient.RemoteConfigUpdate{Parameters: map[string]firebaseclient.RemoteConfigParameter{"welcome": stringParameter("hotfix")}}
+	if _, _, err := client.PublishRemoteConfig(context.Background(), project, "*", hotfix); err != nil {
+		t.Fatalf("PublishRemoteConfig() = %v", err)
+	}
+}
+
+func TestPublishIfOpen(t *testing.T) {
+	t.Parallel()
+
+	client, transport := newFakeClient()
+	r := &RemoteConfigScheduleResource{client: client}
+	data := openSchedule("my-project")
+
+	var diags diag.Diagnostics
+	r.publishIfOpen(context.Background(), data, &diags)
+	if diags.HasError() || len(diags) > 0 {
+		t.Fatalf("publishIfOpen() diagnostics = %v", diags)
+	}
+
+	if data.Status.ValueString() != scheduleStatusPublished || data.PublishedVersion.ValueString() != "2" {
+		t.Errorf("status = %s, published_version = %s, want published as version 2", data.Status, data.PublishedVersion)
+	}
+	if validated := transport.sent("validateOnly=true"); len(validated) != 1 {
+		t.Errorf("publishIfOpen() validated %d templates, want 1", len(validated))
+	}
+	if etags := transport.ifMatch(); len(etags) != 2 || etags[1] == "*" {
+		t.Errorf("If-Match = %v, want the publish over the etag just read", etags)
+	}
+}
+
+func TestPublishIfOpenChangedSinceScheduled(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient()
+	r := &RemoteConfigScheduleResource{client: client}
+	data := openSchedule("my-project")
+	publishHotfix(t, client, "my-project")
+
+	var diags diag.Diagnostics
+	r.publishIfOpen(context.Background(), data, &diags)
+	if !diags.HasError() || diags[0].Summary() != "Remote Config Changed Since Scheduled" {
+		t.Fatalf("publishIfOpen() diagnostics = %v, want Remote Config Changed Since Scheduled", diags)
+	}
+	if data.Status.ValueString() != scheduleStatusPending {
+		t.Errorf("status = %s, want pending", data.Status)
+	}
+
+	live, _, err := client.GetRemoteConfig(context.Background(), "my-project", "")
+	if err != nil {
+		t.Fatalf("GetRemoteConfig() = %v", err)
+	}
+	if got := live.Parameters["welcome"].DefaultValue.Value; got != "hotfix" {
+		t.Errorf("welcome = %q, want the hotfix kept", got)
+	}
+}
+
+func TestPublishIfOpenForce(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient()
+	r := &RemoteConfigScheduleResource{client: client}
+	data := openSchedule("my-project")
+	data.ForcePublish = types.BoolValue(true)
+	publishHotfix(t, client, "my-project")
+	publishHotfix(t, client, "my-project")
+
+	var diags diag.Diagnostics
+	r.publishIfOpen(context.Background(), data, &diags)
+	if diags.HasError() {
+		t.Fatalf("publishIfOpen() diagnostics = %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Summary() != "Remote Config Changes Overridden" {
+		t.Errorf("diagnostics = %v, want Remote Config Changes Overridden", diags)
+	}
+	if data.PublishedVersion.ValueString() != "4" {
+		t.Errorf("published_version = %s, want 4", data.PublishedVersion)
+	}
+}
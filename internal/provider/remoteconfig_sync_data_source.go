@@ -0,0 +1,270 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RemoteConfigSyncDataSource{}
+
+func NewRemoteConfigSyncDataSource() datasource.DataSource {
+	return &RemoteConfigSyncDataSource{}
+}
+
+// RemoteConfigSyncDataSource compares the live templates of two projects.
+type RemoteConfigSyncDataSource struct {
+	client *FirebaseClient
+}
+
+// RemoteConfigSyncDataSourceModel describes the data source data model.
+type RemoteConfigSyncDataSourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Project             types.String `tfsdk:"project"`
+	OtherProject        types.String `tfsdk:"other_project"`
+	Namespace           types.String `tfsdk:"namespace"`
+	IgnoreParameters    types.Set    `tfsdk:"ignore_parameters"`
+	InSync              types.Bool   `tfsdk:"in_sync"`
+	DifferingParameters types.List   `tfsdk:"differing_parameters"`
+	DifferingConditions types.List   `tfsdk:"differing_conditions"`
+}
+
+func (d *RemoteConfigSyncDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remoteconfig_sync"
+}
+
+func (d *RemoteConfigSyncDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Compares the live Remote Config templates of two projects, e.g. to alert from a `check` block when regional configs drift apart. " +
+			"Parameters are compared on what they serve: value type, default and conditional values, JSON values semantically. Descriptions and groups are not compared",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Templates compared",
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID of the first template",
+			},
+			"other_project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID of the template to compare with",
+			},
+			"namespace": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Namespace of both templates, defaults to the `default_namespace` of the provider",
+				Validators: []validator.String{
+					stringvalidator.OneOf(firebaseclient.RemoteConfigNamespaces...),
+				},
+			},
+			"ignore_parameters": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Parameter keys allowed to differ, e.g. region specific endpoints",
+			},
+			"in_sync": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the templates serve the same parameters and conditions",
+			},
+			"differing_parameters": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Sorted keys of the parameters that differ or exist in only one template",
+			},
+			"differing_conditions": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Sorted names of the conditions whose expression differs or that exist in only one template",
+			},
+		},
+	}
+}
+
+func (d *RemoteConfigSyncDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_remoteconfig_sync", remoteConfigAPIScopes)
+}
+
+func (d *RemoteConfigSyncDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RemoteConfigSyncDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := data.Namespace.ValueString()
+	if data.Namespace.IsNull() {
+		namespace = d.client.defaultNamespace
+	}
+
+	var templates [2]*firebaseclient.RemoteConfigRead
+	for i, project := range []string{data.Project.ValueString(), data.OtherProject.ValueString()} {
+		live, _, err := d.client.GetRemoteConfig(ctx, firebaseclient.TemplateRef(project, namespace), "")
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the remote config of project %s: %s", project, err))
+			return
+		}
+		templates[i] = live
+	}
+
+	var ignored []string
+	if !data.IgnoreParameters.IsNull() {
+		resp.Diagnostics.Append(data.IgnoreParameters.ElementsAs(ctx, &ignored, false)...)
+	}
+	params := differingParameters(templates[0], templates[1], ignored)
+	conditions := differingConditions(templates[0].Conditions, templates[1].Conditions)
+
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s", firebaseclient.TemplateRef(data.Project.ValueString(), namespace), firebaseclient.TemplateRef(data.OtherProject.ValueString(), namespace)))
+	data.InSync = types.BoolValue(len(params) == 0 && len(conditions) == 0)
+	var diags diag.Diagnostics
+	data.DifferingParameters, diags = types.ListValueFrom(ctx, types.StringType, params)
+	resp.Diagnostics.Append(diags...)
+	data.DifferingConditions, diags = types.ListValueFrom(ctx, types.StringType, conditions)
+	resp.Diagnostics.Append(diags...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// templateParameters returns every parameter of a live template, grouped or
+// not, keyed by name.
+func templateParameters(template *firebaseclient.RemoteConfigRead) map[string]firebaseclient.RemoteConfigParameter {
+	params := maps.Clone(template.Parameters)
+	if params == nil {
+		params = make(map[string]firebaseclient.RemoteConfigParameter)
+	}
+	for _, group := range template.ParameterGroups {
+		maps.Copy(params, group.Parameters)
+	}
+
+	return params
+}
+
+// differingParameters returns the sorted keys of the parameters that serve
+// differently in two templates, or exist in only one of them.
+func differingParameters(a, b *firebaseclient.RemoteConfigRead, ignored []string) []string {
+	paramsA, paramsB := templateParameters(a), templateParameters(b)
+
+	differing := []string{}
+	for _, name := range slices.Sorted(maps.Keys(paramsA)) {
+		if other, ok := paramsB[name]; !ok || !sameServedParameter(paramsA[name], other) {
+			differing = append(differing, name)
+		}
+	}
+	for name := range paramsB {
+		if _, ok := paramsA[name]; !ok {
+			differing = append(differing, name)
+		}
+	}
+	differing = slices.DeleteFunc(differing, func(name string) bool {
+		return slices.Contains(ignored, name)
+	})
+	slices.Sort(differing)
+
+	return differing
+}
+
+// sameServedParameter reports whether two parameters serve the same values.
+func sameServedParameter(a, b firebaseclient.RemoteConfigParameter) bool {
+	valueType := func(p firebaseclient.RemoteConfigParameter) string {
+		if p.ValueType == "" {
+			return defaultValueType
+		}
+		return p.ValueType
+	}
+	if valueType(a) != valueType(b) || !sameConfigValue(valueType(a), a.DefaultValue, b.DefaultValue) {
+		return false
+	}
+	if len(a.ConditionalValues) != len(b.ConditionalValues) {
+		return false
+	}
+	for condition, value := range a.ConditionalValues {
+		other, ok := b.ConditionalValues[condition]
+		if !ok || !sameConfigValue(valueType(a), value, other) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameConfigValue(valueType string, a, b firebaseclient.ConfigValue) bool {
+	if !equivalentValues(valueType, a.Value, b.Value) {
+		return false
+	}
+	a.Value, b.Value = "", ""
+	if a.RolloutValue != nil && b.RolloutValue != nil {
+		if !equivalentValues(valueType, a.RolloutValue.Value, b.RolloutValue.Value) {
+			return false
+		}
+		ra, rb := *a.RolloutValue, *b.RolloutValue
+		ra.Value, rb.Value = "", ""
+		a.RolloutValue, b.RolloutValue = &ra, &rb
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// differingConditions returns the sorted names of the conditions whose
+// expression differs in two templates, or that exist in only one of them.
+func differingConditions(a, b []firebaseclient.RemoteConfigCondition) []string {
+	expressions := func(conditions []firebaseclient.RemoteConfigCondition) map[string]string {
+		m := make(map[string]string, len(conditions))
+		for _, c := range conditions {
+			m[c.Name] = c.Expression
+		}
+		return m
+	}
+	exprA, exprB := expressions(a), expressions(b)
+
+	differing := []string{}
+	for name, expr := range exprA {
+		if other, ok := exprB[name]; !ok || other != expr {
+			differing = append(differing, name)
+		}
+	}
+	for name := range exprB {
+		if _, ok := exprA[name]; !ok {
+			differing = append(differing, name)
+		}
+	}
+	slices.Sort(differing)
+
+	return differing
+}
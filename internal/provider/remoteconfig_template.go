@@ -0,0 +1,522 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultValueType is the value type of parameters that don't set one, and
+// the one the API serves them as.
+const defaultValueType = "STRING"
+
+// remoteConfigParameterAttributes is the schema of a parameter, shared by
+// top level parameters and the parameters of groups.
+func remoteConfigParameterAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: "name, the map key when omitted",
+			Validators: []validator.String{
+				mapKeyNameValidator{},
+			},
+			PlanModifiers: []planmodifier.String{
+				mapKeyNamePlanModifier{},
+			},
+		},
+		"default_value":     defaultValueAttribute("use_in_app_default", "default_value_file", "default_value_kms"),
+		"default_value_kms": kmsValueAttribute(),
+		"default_value_file": schema.StringAttribute{
+			Optional: true,
+			MarkdownDescription: "File holding the default value, e.g. a large JSON payload, relative to the directory Terraform runs in: prefer `${path.module}/...`. " +
+				"The content is published as the default value but never kept in state, `default_value_file_sha256` tracks it instead. Conflicts with `default_value`",
+		},
+		"default_value_file_sha256": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "SHA-256 of the content of `default_value_file`, hashed at plan and from the live value on refresh, so editing the file or the live value plans an update",
+			PlanModifiers: []planmodifier.String{
+				defaultValueFileHashPlanModifier{},
+			},
+		},
+		"use_in_app_default": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Serve no value by default so apps fall back to their in-app default. Conflicts with `default_value`",
+			Validators: []validator.Bool{
+				boolvalidator.Equals(true),
+			},
+		},
+		"description": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "description",
+			Validators: []validator.String{
+				stringvalidator.UTF8LengthAtMost(maxParameterDescriptionLength),
+			},
+		},
+		"value_type": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: "value type, `" + defaultValueType + "` when omitted",
+			Default:             stringdefault.StaticString(defaultValueType),
+		},
+		"renamed_from": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Key the parameter is renamed from. The old key is removed in the same publish that adds this one, carrying over its conditional values when `conditional_values` is omitted. Nothing happens once the old key is gone",
+		},
+		"conditional_values": schema.MapNestedAttribute{
+			Optional:            true,
+			MarkdownDescription: "Values served instead of the default value, keyed by the name of the condition that must match. When omitted the conditional values already published are kept",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"value": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Value served when the condition matches",
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(
+								path.MatchRelative().AtParent().AtName("personalization_value"),
+								path.MatchRelative().AtParent().AtName("rollout_value"),
+							),
+						},
+					},
+					"personalization_value": schema.SingleNestedAttribute{
+						Optional:            true,
+						MarkdownDescription: "Serve the value chosen by a Personalization instead of a static value",
+						Attributes: map[string]schema.Attribute{
+							"personalization_id": schema.StringAttribute{
+								Required:            true,
+								MarkdownDescription: "ID of the Personalization",
+							},
+						},
+					},
+					"rollout_value": schema.SingleNestedAttribute{
+						Optional:            true,
+						MarkdownDescription: "Serve a value to a percentage of the users targeted by a Rollout",
+						Attributes: map[string]schema.Attribute{
+							"rollout_id": schema.StringAttribute{
+								Required:            true,
+								MarkdownDescription: "ID of the Rollout",
+							},
+							"value": schema.StringAttribute{
+								Required:            true,
+								MarkdownDescription: "Value served to the users in the rollout",
+							},
+							"percent": schema.Int64Attribute{
+								Required:            true,
+								MarkdownDescription: "Percentage of the targeted users the value is served to",
+								Validators: []validator.Int64{
+									int64validator.Between(0, 100),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// defaultValueAttribute is the default value of a parameter, set unless
+// one of the alternatives is.
+func defaultValueAttribute(alternatives ...string) schema.StringAttribute {
+	var paths []path.Expression
+	for _, name := range alternatives {
+		paths = append(paths, path.MatchRelative().AtParent().AtName(name))
+	}
+
+	return schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "default_value",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(paths...),
+		},
+		PlanModifiers: []planmodifier.String{
+			defaultValuePlanModifier{},
+		},
+	}
+}
+
+// parameterMapKey returns the map key of the parameter holding the name
+// attribute at p.
+func parameterMapKey(p path.Path) (string, bool) {
+	key, _ := p.ParentPath().Steps().LastStep()
+	k, ok := key.(path.PathStepElementKeyString)
+
+	return string(k), ok
+}
+
+// mapKeyNameValidator checks that the name of a parameter is its map key.
+type mapKeyNameValidator struct{}
+
+func (v mapKeyNameValidator) Description(ctx context.Context) string {
+	return "name must be the map key of the parameter"
+}
+
+func (v mapKeyNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v mapKeyNameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if key, ok := parameterMapKey(req.Path); ok && key != req.ConfigValue.ValueString() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Parameter Name Mismatch", fmt.Sprintf("Parameter %q is named %q, omit the name or make it the map key.", key, req.ConfigValue.ValueString()))
+	}
+}
+
+// parameterKeyPattern matches the keys the API accepts for parameters.
+var parameterKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+const parameterKeySyntax = "must start with a letter or an underscore and contain only letters, digits and underscores"
+
+// parameterKeysValidator checks the keys of a map of parameters, which the
+// API otherwise rejects with a bare 400 at apply time.
+type parameterKeysValidator struct{}
+
+func (v parameterKeysValidator) Description(ctx context.Context) string {
+	return "parameter keys " + parameterKeySyntax
+}
+
+func (v parameterKeysValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v parameterKeysValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(req.ConfigValue.Elements())) {
+		if !parameterKeyPattern.MatchString(key) {
+			resp.Diagnostics.AddAttributeError(req.Path.AtMapKey(key), "Invalid Parameter Key", fmt.Sprintf("Parameter key %q is invalid, keys %s.", key, parameterKeySyntax))
+		}
+	}
+}
+
+// mapKeyNamePlanModifier plans the name of a parameter as its map key when
+// omitted.
+type mapKeyNamePlanModifier struct{}
+
+func (m mapKeyNamePlanModifier) Description(ctx context.Context) string {
+	return "defaults to the map key of the parameter"
+}
+
+func (m mapKeyNamePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m mapKeyNamePlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	if key, ok := parameterMapKey(req.Path); ok {
+		resp.PlanValue = types.StringValue(key)
+	}
+}
+
+// parameterToAPI converts a parameter model into its API representation.
+func parameterToAPI(param RemoteConfigParameterModel) firebaseclient.RemoteConfigParameter {
+	p := firebaseclient.RemoteConfigParameter{
+		DefaultValue: firebaseclient.ConfigValue{
+			Value:           param.DefaultValue.ValueString(),
+			UseInAppDefault: param.UseInAppDefault.ValueBool(),
+		},
+		Description: param.Description.ValueString(),
+		ValueType:   param.ValueType.ValueString(),
+	}
+	if param.DefaultValueKMS != nil {
+		// Decrypted only when sent to the API.
+		p.DefaultValue.Value = encryptedValuePlaceholder(param.DefaultValueKMS)
+	}
+	if !param.DefaultValueFile.IsNull() {
+		// Read only when sent to the API.
+		p.DefaultValue.Value = fileValuePlaceholder(param.DefaultValueFileSHA256.ValueString())
+	}
+
+	if param.ConditionalValues != nil {
+		p.ConditionalValues = make(map[string]firebaseclient.ConfigValue, len(param.ConditionalValues))
+		for condition, value := range param.ConditionalValues {
+			p.ConditionalValues[condition] = conditionalValueToAPI(value)
+		}
+	}
+
+	return p
+}
+
+// conditionalValueToAPI converts a conditional value model into its API
+// representation.
+func conditionalValueToAPI(value RemoteConfigConditionalValueModel) firebaseclient.ConfigValue {
+	if value.PersonalizationValue != nil {
+		return firebaseclient.ConfigValue{
+			PersonalizationValue: &firebaseclient.PersonalizationValue{
+				PersonalizationID: value.PersonalizationValue.PersonalizationID.ValueString(),
+			},
+		}
+	}
+
+	if value.RolloutValue != nil {
+		return firebaseclient.ConfigValue{
+			RolloutValue: &firebaseclient.RolloutValue{
+				RolloutID: value.RolloutValue.RolloutID.ValueString(),
+				Value:     value.RolloutValue.Value.ValueString(),
+				Percent:   value.RolloutValue.Percent.ValueInt64(),
+			},
+		}
+	}
+
+	return firebaseclient.ConfigValue{
+		Value: value.Value.ValueString(),
+	}
+}
+
+// conditionalValueFromAPI converts an API conditional value into its model.
+func conditionalValueFromAPI(value firebaseclient.ConfigValue) RemoteConfigConditionalValueModel {
+	if value.PersonalizationValue != nil {
+		return RemoteConfigConditionalValueModel{
+			Value: types.StringNull(),
+			PersonalizationValue: &RemoteConfigPersonalizationValueModel{
+				PersonalizationID: types.StringValue(value.PersonalizationValue.PersonalizationID),
+			},
+		}
+	}
+
+	if value.RolloutValue != nil {
+		return RemoteConfigConditionalValueModel{
+			Value: types.StringNull(),
+			RolloutValue: &RemoteConfigRolloutValueModel{
+				RolloutID: types.StringValue(value.RolloutValue.RolloutID),
+				Value:     types.StringValue(value.RolloutValue.Value),
+				Percent:   types.Int64Value(value.RolloutValue.Percent),
+			},
+		}
+	}
+
+	return RemoteConfigConditionalValueModel{
+		Value: types.StringValue(value.Value),
+	}
+}
+
+// parameterFromAPI converts an API parameter into its model. Attributes the
+// prior state leaves unmanaged stay unmanaged.
+func parameterFromAPI(name string, p firebaseclient.RemoteConfigParameter, prior *RemoteConfigParameterModel) RemoteConfigParameterModel {
+	param := RemoteConfigParameterModel{
+		Name:             types.StringValue(name),
+		Description:      descriptionValue(p.Description, types.StringNull()),
+		ValueType:        types.StringValue(p.ValueType),
+		DefaultValue:     types.StringValue(p.DefaultValue.Value),
+		UseInAppDefault:  types.BoolNull(),
+		RenamedFrom:      types.StringNull(),
+		DefaultValueFile: types.StringNull(),
+
+		DefaultValueFileSHA256: types.StringNull(),
+	}
+	if p.ValueType == "" || p.ValueType == "PARAMETER_VALUE_TYPE_UNSPECIFIED" {
+		param.ValueType = types.StringValue(defaultValueType)
+	}
+	if prior != nil {
+		param.Description = descriptionValue(p.Description, prior.Description)
+		param.RenamedFrom = prior.RenamedFrom
+		param.DefaultValueFile = prior.DefaultValueFile
+	}
+	if p.DefaultValue.UseInAppDefault {
+		param.DefaultValue = types.StringNull()
+		param.UseInAppDefault = types.BoolValue(true)
+	}
+	if prior != nil && prior.DefaultValueKMS != nil {
+		// The live value was redacted. One that is not the plaintext keeps
+		// an empty ciphertext in state, so the next plan publishes the
+		// configured one again.
+		kms := *prior.DefaultValueKMS
+		if p.DefaultValue.Value != encryptedValuePlaceholder(&kms) {
+			kms.Ciphertext = types.StringValue("")
+		}
+		param.DefaultValueKMS = &kms
+		param.DefaultValue = types.StringNull()
+		param.UseInAppDefault = types.BoolNull()
+	}
+	if prior != nil && !prior.DefaultValueFile.IsNull() {
+		// Only the hash of the live value is kept, the empty one when the
+		// live value is the in-app default.
+		hash := ""
+		if !p.DefaultValue.UseInAppDefault {
+			hash = liveFileValueHash(p.ValueType, p.DefaultValue.Value, *prior)
+		}
+		param.DefaultValueFileSHA256 = types.StringValue(hash)
+		param.DefaultValue = types.StringNull()
+		param.UseInAppDefault = types.BoolNull()
+	}
+
+	if len(p.ConditionalValues) > 0 && (prior == nil || prior.ConditionalValues != nil) {
+		param.ConditionalValues = make(map[string]RemoteConfigConditionalValueModel, len(p.ConditionalValues))
+		for condition, value := range p.ConditionalValues {
+			param.ConditionalValues[condition] = conditionalValueFromAPI(value)
+		}
+	} else if prior != nil && prior.ConditionalValues != nil {
+		param.ConditionalValues = map[string]RemoteConfigConditionalValueModel{}
+	}
+	if prior != nil {
+		keepEquivalentValues(&param, prior)
+	}
+
+	return param
+}
+
+// applyRemoteTemplate refreshes the template attributes of the model from
+// a live template. When importing every attribute is populated, otherwise
+// attributes left unset in the prior state stay unmanaged.
+func applyRemoteTemplate(data *RemoteConfigResourceModel, target *firebaseclient.RemoteConfigRead, importing bool) {
+	priorParams := make(map[string]*RemoteConfigParameterModel)
+	if !importing {
+		for name, param := range data.Parameters {
+			priorParams[name] = &param
+		}
+		for _, group := range data.ParameterGroups {
+			for name, param := range group.Parameters {
+				priorParams[name] = &param
+			}
+		}
+	}
+
+	parameters := make(map[string]RemoteConfigParameterModel, len(target.Parameters))
+	for k, v := range target.Parameters {
+		parameters[k] = parameterFromAPI(k, v, priorParams[k])
+	}
+
+	priorGroups := data.ParameterGroups
+	groups := make(map[string]RemoteConfigParameterGroupModel)
+	for k, v := range target.ParameterGroups {
+		priorDescription := types.StringNull()
+		if prior, ok := priorGroups[k]; ok {
+			priorDescription = prior.Description
+		}
+		if prior, ok := priorGroups[k]; ok && len(prior.Parameters) == 0 {
+			// Description only group, its members are not managed.
+			groups[k] = RemoteConfigParameterGroupModel{
+				Description: descriptionValue(v.Description, priorDescription),
+				Parameters:  prior.Parameters,
+			}
+			continue
+		}
+
+		groups[k] = RemoteConfigParameterGroupModel{
+			Description: descriptionValue(v.Description, priorDescription),
+			Parameters:  make(map[string]RemoteConfigParameterModel),
+		}
+
+		for paramName, paramValue := range v.Parameters {
+			groups[k].Parameters[paramName] = parameterFromAPI(paramName, paramValue, priorParams[paramName])
+		}
+	}
+
+	if importing || data.Conditions != nil {
+		// The expression is refreshed, the match it was compiled from kept.
+		priorMatches := make(map[string]*RemoteConfigConditionMatchModel, len(data.Conditions))
+		for _, c := range data.Conditions {
+			priorMatches[c.Name.ValueString()] = c.Match
+		}
+		data.Conditions = nil
+		for _, c := range target.Conditions {
+			tagColor := types.StringNull()
+			if c.TagColor != "" && c.TagColor != "CONDITION_DISPLAY_COLOR_UNSPECIFIED" {
+				tagColor = types.StringValue(c.TagColor)
+			}
+			data.Conditions = append(data.Conditions, RemoteConfigConditionModel{
+				Name:       types.StringValue(c.Name),
+				Expression: types.StringValue(c.Expression),
+				Match:      priorMatches[c.Name],
+				TagColor:   tagColor,
+			})
+		}
+		if data.Conditions == nil && !importing {
+			data.Conditions = []RemoteConfigConditionModel{}
+		}
+	}
+
+	data.Parameters = parameters
+	data.ParameterGroups = groups
+}
+
+// buildRemoteConfigUpdate converts the resource model into the publish
+// payload, starting from template_json when set.
+func buildRemoteConfigUpdate(ctx context.Context, data *RemoteConfigResourceModel) (firebaseclient.RemoteConfigUpdate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	payload := firebaseclient.RemoteConfigUpdate{
+		Parameters:      make(map[string]firebaseclient.RemoteConfigParameter),
+		ParameterGroups: make(map[string]firebaseclient.RemoteConfigParameterGroup),
+	}
+	if !data.TemplateJSON.IsNull() {
+		template, err := decodeTemplateJSON(data.TemplateJSON.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("template_json"), "Invalid Template JSON", err.Error())
+			return payload, diags
+		}
+		payload = template
+	}
+	for name, item := range data.Parameters {
+		payload.Parameters[name] = parameterToAPI(item)
+	}
+
+	for name, item := range data.ParameterGroups {
+		group := firebaseclient.RemoteConfigParameterGroup{
+			Description: item.Description.ValueString(),
+			Parameters:  make(map[string]firebaseclient.RemoteConfigParameter),
+		}
+
+		for pname, param := range item.Parameters {
+			group.Parameters[pname] = parameterToAPI(param)
+		}
+		payload.ParameterGroups[name] = group
+	}
+
+	// A null conditions attribute leaves the live conditions untouched.
+	if data.Conditions != nil {
+		payload.Conditions = make([]firebaseclient.RemoteConfigCondition, 0, len(data.Conditions))
+		for _, c := range data.Conditions {
+			payload.Conditions = append(payload.Conditions, firebaseclient.RemoteConfigCondition{
+				Name:       c.Name.ValueString(),
+				Expression: c.Expression.ValueString(),
+				TagColor:   c.TagColor.ValueString(),
+			})
+		}
+	}
+
+	var metadata remoteConfigMetadata
+	var d diag.Diagnostics
+	metadata.Labels, d = stringMapFromValue(ctx, data.Labels)
+	diags.Append(d...)
+	metadata.Annotations, d = stringMapFromValue(ctx, data.Annotations)
+	diags.Append(d...)
+
+	description, err := encodeVersionDescription(metadata)
+	if err != nil {
+		diags.AddAttributeError(path.Root("labels"), "Invalid Version Metadata", err.Error())
+		return payload, diags
+	}
+	if description != "" {
+		payload.Version = &firebaseclient.RemoteConfigVersionUpdate{Description: description}
+	}
+
+	return payload, diags
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+var _ resource.ResourceWithUpgradeState = &RemoteConfigResource{}
+
+func (r *RemoteConfigResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 held the top level parameters in a list.
+		0: {StateUpgrader: upgradeRemoteConfigStateV0},
+	}
+}
+
+// upgradeRemoteConfigStateV0 keys the top level parameters by name. The
+// state is rewritten as JSON, so attributes added since are left null
+// until the next refresh.
+func upgradeRemoteConfigStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to Upgrade State", "The version 0 state of firebaseextra_remoteconfig is not stored as JSON.")
+		return
+	}
+
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade State", fmt.Sprintf("Unable to decode the version 0 state: %s", err))
+		return
+	}
+
+	var parameters []map[string]json.RawMessage
+	if err := json.Unmarshal(state["parameters"], &parameters); err != nil && state["parameters"] != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade State", fmt.Sprintf("Unable to decode the parameters of the version 0 state: %s", err))
+		return
+	}
+	if parameters != nil {
+		byName := make(map[string]map[string]json.RawMessage, len(parameters))
+		for _, param := range parameters {
+			var name string
+			if err := json.Unmarshal(param["name"], &name); err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade State", fmt.Sprintf("Parameter without a name in the version 0 state: %s", err))
+				return
+			}
+			byName[name] = param
+		}
+
+		var err error
+		if state["parameters"], err = json.Marshal(byName); err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade State", err.Error())
+			return
+		}
+	}
+
+	upgraded, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade State", err.Error())
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
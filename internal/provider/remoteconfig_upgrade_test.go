@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeRemoteConfigState runs a version 0 state of
+// firebaseextra_remoteconfig through the provider server, like Terraform
+// does, and returns its upgraded attributes.
+func upgradeRemoteConfigState(t *testing.T, raw *tfprotov6.RawState) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+	ctx := context.Background()
+
+	server := providerserver.NewProtocol6(New("test")())()
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema() = %v", err)
+	}
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "firebaseextra_remoteconfig",
+		Version:  0,
+		RawState: raw,
+	})
+	if err != nil {
+		t.Fatalf("UpgradeResourceState() = %v", err)
+	}
+	if resp.UpgradedState == nil {
+		return nil, resp.Diagnostics
+	}
+
+	value, err := resp.UpgradedState.Unmarshal(schemas.ResourceSchemas["firebaseextra_remoteconfig"].ValueType())
+	if err != nil {
+		t.Fatalf("unable to decode the upgraded state: %v", err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatalf("unable to decode the upgraded state: %v", err)
+	}
+
+	return attributes, resp.Diagnostics
+}
+
+func TestUpgradeRemoteConfigStateV0(t *testing.T) {
+	t.Parallel()
+
+	attributes, diags := upgradeRemoteConfigState(t, &tfprotov6.RawState{JSON: []byte(`{
+		"id": "my-project", "project": "my-project", "version": "3", "etag": "etag-3",
+		"parameters": [
+			{"name": "welcome", "description": "Greeting", "value_type": "STRING", "default_value": "hello", "conditional_values": null},
+			{"name": "limit", "description": null, "value_type": "NUMBER", "default_value": "10", "conditional_values": null}
+		],
+		"parameter_groups": null
+	}`)})
+	if len(diags) > 0 {
+		t.Fatalf("UpgradeResourceState() diagnostics = %v", diags[0])
+	}
+
+	var parameters map[string]tftypes.Value
+	if err := attributes["parameters"].As(&parameters); err != nil {
+		t.Fatalf("parameters is not a map: %v", err)
+	}
+	var names []string
+	for name := range parameters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if strings.Join(names, ",") != "limit,welcome" {
+		t.Fatalf("parameters = %v, want limit and welcome", names)
+	}
+
+	var welcome map[string]tftypes.Value
+	if err := parameters["welcome"].As(&welcome); err != nil {
+		t.Fatalf("welcome is not an object: %v", err)
+	}
+	var defaultValue string
+	if err := welcome["default_value"].As(&defaultValue); err != nil || defaultValue != "hello" {
+		t.Errorf("welcome.default_value = %q (%v), want hello", defaultValue, err)
+	}
+
+	var version string
+	if err := attributes["version"].As(&version); err != nil || version != "3" {
+		t.Errorf("version = %q (%v), want 3", version, err)
+	}
+	// Attributes added since version 0 are left null until the next refresh.
+	if !attributes["changed_parameters"].IsNull() {
+		t.Errorf("changed_parameters = %v, want null", attributes["changed_parameters"])
+	}
+}
+
+func TestUpgradeRemoteConfigStateV0NullParameters(t *testing.T) {
+	t.Parallel()
+
+	attributes, diags := upgradeRemoteConfigState(t, &tfprotov6.RawState{JSON: []byte(`{"id": "p", "project": "p", "parameters": null}`)})
+	if len(diags) > 0 {
+		t.Fatalf("UpgradeResourceState() diagnostics = %v", diags[0])
+	}
+	if !attributes["parameters"].IsNull() {
+		t.Errorf("parameters = %v, want null", attributes["parameters"])
+	}
+}
+
+func TestUpgradeRemoteConfigStateV0Errors(t *testing.T) {
+	t.Parallel()
+
+	for name, raw := range map[string]*tfprotov6.RawState{
+		"flatmap":           {Flatmap: map[string]string{"id": "p"}},
+		"invalid json":      {JSON: []byte(`{"id": `)},
+		"parameters":        {JSON: []byte(`{"id": "p", "parameters": {"welcome": "hello"}}`)},
+		"unnamed parameter": {JSON: []byte(`{"id": "p", "parameters": [{"default_value": "hello"}]}`)},
+	} {
+		_, diags := upgradeRemoteConfigState(t, raw)
+		if len(diags) == 0 || diags[0].Severity != tfprotov6.DiagnosticSeverityError || diags[0].Summary != "Unable to Upgrade State" {
+			t.Errorf("%s: diagnostics = %v, want an Unable to Upgrade State error", name, diags)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// getValidatableConfig reads the resource configuration for validation. It
+// returns false when the configuration still holds unknown collections,
+// which can only be validated once they are known during apply.
+func getValidatableConfig(ctx context.Context, req resource.ValidateConfigRequest) (*RemoteConfigResourceModel, bool) {
+	var data RemoteConfigResourceModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		return nil, false
+	}
+
+	return &data, true
+}
+
+// forEachParameter calls fn for every top level and grouped parameter with
+// the path of the parameter in the configuration.
+func forEachParameter(data *RemoteConfigResourceModel, fn func(p path.Path, name string, param RemoteConfigParameterModel)) {
+	for _, name := range slices.Sorted(maps.Keys(data.Parameters)) {
+		fn(path.Root("parameters").AtMapKey(name), name, data.Parameters[name])
+	}
+
+	groupNames := slices.Sorted(maps.Keys(data.ParameterGroups))
+	for _, groupName := range groupNames {
+		group := data.ParameterGroups[groupName]
+		for _, name := range slices.Sorted(maps.Keys(group.Parameters)) {
+			fn(path.Root("parameter_groups").AtMapKey(groupName).AtName("parameters").AtMapKey(name), name, group.Parameters[name])
+		}
+	}
+}
+
+// conditionReferencesValidator checks that conditional values only refer to
+// declared conditions, since the API rejects unknown ones with a cryptic
+// error at apply time, and warns about conditions nothing refers to.
+type conditionReferencesValidator struct{}
+
+func (v conditionReferencesValidator) Description(ctx context.Context) string {
+	return "conditional values must reference declared conditions"
+}
+
+func (v conditionReferencesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v conditionReferencesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	data, ok := getValidatableConfig(ctx, req)
+	if !ok || data.Conditions == nil {
+		// Unmanaged conditions live in the remote template only.
+		return
+	}
+
+	declared := make(map[string]bool, len(data.Conditions))
+	for i, c := range data.Conditions {
+		if c.Name.IsUnknown() {
+			return
+		}
+		declared[c.Name.ValueString()] = false
+
+		if data.Canary != nil && data.Canary.Condition.Equal(c.Name) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("conditions").AtListIndex(i),
+				"Canary Condition Declared",
+				fmt.Sprintf("Condition %q is generated by canary and can't be declared.", c.Name.ValueString()),
+			)
+		}
+	}
+
+	forEachParameter(data, func(p path.Path, name string, param RemoteConfigParameterModel) {
+		for _, condition := range slices.Sorted(maps.Keys(param.ConditionalValues)) {
+			if _, ok := declared[condition]; !ok {
+				resp.Diagnostics.AddAttributeError(
+					p.AtName("conditional_values").AtMapKey(condition),
+					"Unknown Condition",
+					fmt.Sprintf("Parameter %q has a conditional value for condition %q, which is not declared in conditions.", name, condition),
+				)
+				continue
+			}
+			declared[condition] = true
+		}
+	})
+
+	for i, c := range data.Conditions {
+		if !declared[c.Name.ValueString()] {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("conditions").AtListIndex(i),
+				"Unused Condition",
+				fmt.Sprintf("Condition %q is not referenced by any conditional value.", c.Name.ValueString()),
+			)
+		}
+	}
+}
+
+// duplicateParametersValidator checks that a parameter key is declared only
+// once across the top level and all groups, the API rejects a template that
+// holds a key twice.
+type duplicateParametersValidator struct{}
+
+func (v duplicateParametersValidator) Description(ctx context.Context) string {
+	return "parameter keys must be unique across parameters and parameter_groups"
+}
+
+func (v duplicateParametersValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v duplicateParametersValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	data, ok := getValidatableConfig(ctx, req)
+	if !ok {
+		return
+	}
+
+	location := func(p path.Path) string {
+		if group, ok := parameterMapKey(p.ParentPath()); ok {
+			return fmt.Sprintf("group %q", group)
+		}
+		return "the top level"
+	}
+
+	declared := make(map[string]path.Path)
+	forEachParameter(data, func(p path.Path, name string, param RemoteConfigParameterModel) {
+		first, ok := declared[name]
+		if !ok {
+			declared[name] = p
+			return
+		}
+		resp.Diagnostics.AddAttributeError(
+			p,
+			"Duplicate Parameter",
+			fmt.Sprintf("Parameter %q is declared in %s and in %s, a key can only be declared once in a template.", name, location(first), location(p)),
+		)
+	})
+}
+
+// jsonValuesValidator checks that the values of JSON parameters parse as
+// JSON, which the API otherwise rejects with a bare 400 at apply time.
+type jsonValuesValidator struct{}
+
+func (v jsonValuesValidator) Description(ctx context.Context) string {
+	return "values of parameters with value_type JSON must be valid JSON"
+}
+
+func (v jsonValuesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonValuesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	data, ok := getValidatableConfig(ctx, req)
+	if !ok {
+		return
+	}
+
+	check := func(p path.Path, name string, value types.String) {
+		if value.IsNull() || value.IsUnknown() {
+			return
+		}
+		var decoded any
+		if err := json.Unmarshal([]byte(value.ValueString()), &decoded); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				p,
+				"Invalid JSON Value",
+				fmt.Sprintf("Parameter %q has value_type JSON but this value does not parse as JSON: %s", name, err),
+			)
+		}
+	}
+
+	forEachParameter(data, func(p path.Path, name string, param RemoteConfigParameterModel) {
+		if param.ValueType.ValueString() != "JSON" {
+			return
+		}
+
+		check(p.AtName("default_value"), name, param.DefaultValue)
+		for _, condition := range slices.Sorted(maps.Keys(param.ConditionalValues)) {
+			value := param.ConditionalValues[condition]
+			check(p.AtName("conditional_values").AtMapKey(condition).AtName("value"), name, value.Value)
+			if value.RolloutValue != nil {
+				check(p.AtName("conditional_values").AtMapKey(condition).AtName("rollout_value").AtName("value"), name, value.RolloutValue.Value)
+			}
+		}
+	})
+}
@@ -0,0 +1,351 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fileValuePrefix starts the placeholders standing for the content of a
+// default_value_file.
+const fileValuePrefix = "file-sha256:"
+
+// fileValueHash returns the hash default_value_file_sha256 holds for a
+// value.
+func fileValueHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// fileValuePlaceholder stands for the content of a default_value_file
+// wherever the content itself doesn't need to be: the payloads compared and
+// hashed, the rendered template and the live template kept in private
+// state. The content is only read when publishing.
+func fileValuePlaceholder(hash string) string {
+	return fileValuePrefix + hash
+}
+
+// defaultValuePlanModifier plans the default value of a parameter as null
+// when it is not configured, the value coming from default_value_file,
+// default_value_kms or the in-app default instead.
+type defaultValuePlanModifier struct{}
+
+func (m defaultValuePlanModifier) Description(ctx context.Context) string {
+	return "null unless configured"
+}
+
+func (m defaultValuePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m defaultValuePlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.ConfigValue.IsNull() {
+		resp.PlanValue = types.StringNull()
+	}
+}
+
+// defaultValueFileHashPlanModifier plans the hash of the content of the
+// default_value_file of a parameter. Only the hash ends up in the plan and
+// the state, so both a changed file and a changed live value show up as a
+// diff of default_value_file_sha256 without copying the content around.
+type defaultValueFileHashPlanModifier struct{}
+
+func (m defaultValueFileHashPlanModifier) Description(ctx context.Context) string {
+	return "the SHA-256 of the content of default_value_file when set"
+}
+
+func (m defaultValueFileHashPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m defaultValueFileHashPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	filePath := req.Path.ParentPath().AtName("default_value_file")
+	var file types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, filePath, &file)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if file.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	if file.IsNull() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+
+	content, err := os.ReadFile(file.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(filePath, "Unreadable Default Value File", fmt.Sprintf("Unable to read the default value of the parameter: %s", err))
+		return
+	}
+
+	var valueType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("value_type"), &valueType)...)
+	if valueType.ValueString() == "JSON" {
+		var decoded any
+		if err := json.Unmarshal(content, &decoded); err != nil {
+			resp.Diagnostics.AddAttributeError(filePath, "Invalid JSON Value", fmt.Sprintf("The parameter has value_type JSON but %s does not parse as JSON: %s", file.ValueString(), err))
+			return
+		}
+	}
+
+	resp.PlanValue = types.StringValue(fileValueHash(string(content)))
+}
+
+// liveFileValueHash returns the hash of the live default value of a
+// parameter reading it from a file, which may already be redacted. A JSON
+// value only reformatted in the console hashes as the file does.
+func liveFileValueHash(valueType, live string, param RemoteConfigParameterModel) string {
+	if strings.HasPrefix(live, fileValuePrefix) {
+		return strings.TrimPrefix(live, fileValuePrefix)
+	}
+	hash := fileValueHash(live)
+	planned := param.DefaultValueFileSHA256.ValueString()
+	if hash == planned || planned == "" || valueType != "JSON" {
+		return hash
+	}
+
+	content, err := os.ReadFile(param.DefaultValueFile.ValueString())
+	if err == nil && fileValueHash(string(content)) == planned && equivalentValues(valueType, live, string(content)) {
+		return planned
+	}
+
+	return hash
+}
+
+// redactLiveValues redacts a live template before anything of it is kept:
+// encrypted values, see redactEncryptedValues, and the contents of default
+// value files, see redactFileValues.
+func (c *FirebaseClient) redactLiveValues(ctx context.Context, data *RemoteConfigResourceModel, target *firebaseclient.RemoteConfigRead, verify bool) error {
+	if err := c.redactEncryptedValues(ctx, data, target, verify); err != nil {
+		return err
+	}
+
+	return redactFileValues(data, target)
+}
+
+// redactFileValues replaces the live default values of the parameters the
+// model reads from files with the placeholders of their hashes, in the
+// parsed template and in its raw JSON, so the contents don't end up in
+// state. The value a canary routes through its condition is redacted too.
+func redactFileValues(data *RemoteConfigResourceModel, target *firebaseclient.RemoteConfigRead) error {
+	declared := make(map[string]RemoteConfigParameterModel)
+	forEachParameter(data, func(_ path.Path, name string, param RemoteConfigParameterModel) {
+		if !param.DefaultValueFile.IsNull() {
+			declared[name] = param
+		}
+	})
+	if len(declared) == 0 {
+		return nil
+	}
+	canary := ""
+	if data.Canary != nil && !data.Canary.Promote.ValueBool() {
+		canary = data.Canary.Condition.ValueString()
+	}
+
+	redacted := make(map[string]firebaseclient.RemoteConfigParameter)
+	redact := func(params map[string]firebaseclient.RemoteConfigParameter) {
+		for name, param := range params {
+			decl, ok := declared[name]
+			if !ok {
+				continue
+			}
+			if !param.DefaultValue.UseInAppDefault {
+				param.DefaultValue.Value = fileValuePlaceholder(liveFileValueHash(param.ValueType, param.DefaultValue.Value, decl))
+			}
+			if value, ok := param.ConditionalValues[canary]; ok && canary != "" && value.PersonalizationValue == nil && value.RolloutValue == nil {
+				value.Value = fileValuePlaceholder(liveFileValueHash(param.ValueType, value.Value, decl))
+				param.ConditionalValues = maps.Clone(param.ConditionalValues)
+				param.ConditionalValues[canary] = value
+			}
+			params[name] = param
+			redacted[name] = param
+		}
+	}
+	redact(target.Parameters)
+	for _, group := range target.ParameterGroups {
+		redact(group.Parameters)
+	}
+	if len(redacted) == 0 || len(target.Raw) == 0 {
+		return nil
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(target.Raw, &raw); err != nil {
+		return fmt.Errorf("unable to redact default value files: %w", err)
+	}
+	redactRaw := func(params any) {
+		members, _ := params.(map[string]any)
+		for name, param := range members {
+			p, ok := param.(map[string]any)
+			r, isRedacted := redacted[name]
+			if !ok || !isRedacted {
+				continue
+			}
+			p["defaultValue"] = r.DefaultValue
+			if conditional, ok := p["conditionalValues"].(map[string]any); ok && canary != "" {
+				if value, ok := r.ConditionalValues[canary]; ok {
+					conditional[canary] = value
+				}
+			}
+		}
+	}
+	redactRaw(raw["parameters"])
+	groups, _ := raw["parameterGroups"].(map[string]any)
+	for _, group := range groups {
+		if g, ok := group.(map[string]any); ok {
+			redactRaw(g["parameters"])
+		}
+	}
+	redactedRaw, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("unable to redact default value files: %w", err)
+	}
+	target.Raw = redactedRaw
+
+	return nil
+}
+
+// valueFiles indexes the files of the parameters of the models reading
+// their default value from one by the placeholder of their planned content.
+func valueFiles(models ...*RemoteConfigResourceModel) map[string]string {
+	files := make(map[string]string)
+	for _, data := range models {
+		if data == nil {
+			continue
+		}
+		forEachParameter(data, func(_ path.Path, _ string, param RemoteConfigParameterModel) {
+			addValueFile(files, param)
+		})
+	}
+
+	return files
+}
+
+// addValueFile indexes the file of a parameter, if it reads its default
+// value from one.
+func addValueFile(files map[string]string, param RemoteConfigParameterModel) {
+	if !param.DefaultValueFile.IsNull() && !param.DefaultValueFileSHA256.IsNull() && !param.DefaultValueFileSHA256.IsUnknown() {
+		files[fileValuePlaceholder(param.DefaultValueFileSHA256.ValueString())] = param.DefaultValueFile.ValueString()
+	}
+}
+
+// readValueFiles replaces the placeholders of file contents in a template
+// about to be sent to the API with the contents of the files, indexed by
+// valueFiles. A file whose content no longer is the one planned fails, the
+// plan would not be what is published. The maps of the payload are copied,
+// not modified.
+func readValueFiles(payload firebaseclient.RemoteConfigUpdate, files map[string]string) (firebaseclient.RemoteConfigUpdate, error) {
+	if len(files) == 0 {
+		return payload, nil
+	}
+
+	contents := make(map[string]string)
+	read := func(name string, value firebaseclient.ConfigValue) (firebaseclient.ConfigValue, error) {
+		if !strings.HasPrefix(value.Value, fileValuePrefix) {
+			return value, nil
+		}
+		file, ok := files[value.Value]
+		if !ok {
+			return value, fmt.Errorf("the default value of parameter %s is the content of a file the configuration no longer declares", name)
+		}
+		content, ok := contents[value.Value]
+		if !ok {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return value, fmt.Errorf("unable to read the default value of parameter %s: %w", name, err)
+			}
+			content = string(data)
+			if fileValuePlaceholder(fileValueHash(content)) != value.Value {
+				return value, fmt.Errorf("%s, the default value of parameter %s, changed since the plan, plan again", file, name)
+			}
+			contents[value.Value] = content
+		}
+		value.Value = content
+		return value, nil
+	}
+
+	readAll := func(params map[string]firebaseclient.RemoteConfigParameter) (map[string]firebaseclient.RemoteConfigParameter, error) {
+		if params == nil {
+			return nil, nil
+		}
+		resolved := make(map[string]firebaseclient.RemoteConfigParameter, len(params))
+		for name, param := range params {
+			var err error
+			if param, err = readValueFile(param, name, read); err != nil {
+				return nil, err
+			}
+			resolved[name] = param
+		}
+		return resolved, nil
+	}
+
+	parameters, err := readAll(payload.Parameters)
+	if err != nil {
+		return payload, err
+	}
+	groups := make(map[string]firebaseclient.RemoteConfigParameterGroup, len(payload.ParameterGroups))
+	for name, group := range payload.ParameterGroups {
+		if group.Parameters, err = readAll(group.Parameters); err != nil {
+			return payload, err
+		}
+		groups[name] = group
+	}
+	payload.Parameters = parameters
+	if payload.ParameterGroups != nil {
+		payload.ParameterGroups = groups
+	}
+
+	return payload, nil
+}
+
+// readValueFile reads the default value of a parameter and its conditional
+// values, which hold the content too when routed through a canary.
+func readValueFile(param firebaseclient.RemoteConfigParameter, name string, read func(string, firebaseclient.ConfigValue) (firebaseclient.ConfigValue, error)) (firebaseclient.RemoteConfigParameter, error) {
+	var err error
+	if param.DefaultValue, err = read(name, param.DefaultValue); err != nil {
+		return param, err
+	}
+	if param.ConditionalValues == nil {
+		return param, nil
+	}
+
+	values := make(map[string]firebaseclient.ConfigValue, len(param.ConditionalValues))
+	for condition, value := range param.ConditionalValues {
+		if values[condition], err = read(name, value); err != nil {
+			return param, err
+		}
+	}
+	param.ConditionalValues = values
+
+	return param, nil
+}
+
+// readParameterFile is readValueFiles for a single parameter.
+func readParameterFile(p firebaseclient.RemoteConfigParameter, param RemoteConfigParameterModel) (firebaseclient.RemoteConfigParameter, error) {
+	files := make(map[string]string)
+	addValueFile(files, param)
+	payload, err := readValueFiles(firebaseclient.RemoteConfigUpdate{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{param.Name.ValueString(): p},
+	}, files)
+	if err != nil {
+		return p, err
+	}
+
+	return payload.Parameters[param.Name.ValueString()], nil
+}
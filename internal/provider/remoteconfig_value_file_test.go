@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// valueFile writes a default value file and returns its path.
+func valueFile(t *testing.T, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "value.json")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+	return file
+}
+
+// fileParameter returns a JSON parameter reading its default value from
+// file, planned with the hash of content.
+func fileParameter(file, content string) RemoteConfigParameterModel {
+	return RemoteConfigParameterModel{
+		Name:                   types.StringValue("payload"),
+		ValueType:              types.StringValue("JSON"),
+		DefaultValue:           types.StringNull(),
+		DefaultValueFile:       types.StringValue(file),
+		DefaultValueFileSHA256: types.StringValue(fileValueHash(content)),
+	}
+}
+
+func TestDefaultValueFileHashPlanModifier(t *testing.T) {
+	t.Parallel()
+
+	content := `{"items": [1, 2, 3]}`
+	tests := map[string]struct {
+		file      tftypes.Value
+		valueType string
+
+		wantHash    types.String
+		wantSummary string
+	}{
+		"file": {
+			file: tftypes.NewValue(tftypes.String, valueFile(t, content)), valueType: "JSON",
+			wantHash: types.StringValue(fileValueHash(content)),
+		},
+		"no file": {
+			file: tftypes.NewValue(tftypes.String, nil), valueType: "JSON",
+			wantHash: types.StringNull(),
+		},
+		"unknown file": {
+			file: tftypes.NewValue(tftypes.String, tftypes.UnknownValue), valueType: "JSON",
+			wantHash: types.StringUnknown(),
+		},
+		"invalid JSON": {
+			file: tftypes.NewValue(tftypes.String, valueFile(t, "{")), valueType: "JSON",
+			wantHash: types.StringUnknown(), wantSummary: "Invalid JSON Value",
+		},
+		"string": {
+			file: tftypes.NewValue(tftypes.String, valueFile(t, "{")), valueType: "STRING",
+			wantHash: types.StringValue(fileValueHash("{")),
+		},
+		"missing file": {
+			file: tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing.json")), valueType: "JSON",
+			wantHash: types.StringUnknown(), wantSummary: "Unreadable Default Value File",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			r := &RemoteConfigResource{}
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			raw := objectValue(t, schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+				"project": tftypes.NewValue(tftypes.String, "my-project"),
+				"parameters": remoteConfigParameters(t, r, map[string]map[string]tftypes.Value{
+					"payload": {
+						"value_type":         tftypes.NewValue(tftypes.String, test.valueType),
+						"default_value_file": test.file,
+					},
+				}),
+			})
+
+			req := planmodifier.StringRequest{
+				Path:      path.Root("parameters").AtMapKey("payload").AtName("default_value_file_sha256"),
+				Config:    tfsdk.Config{Schema: schemaResp.Schema, Raw: raw},
+				PlanValue: types.StringUnknown(),
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			defaultValueFileHashPlanModifier{}.PlanModifyString(ctx, req, resp)
+
+			switch {
+			case test.wantSummary == "" && resp.Diagnostics.HasError():
+				t.Fatalf("PlanModifyString() diagnostics = %v", resp.Diagnostics)
+			case test.wantSummary != "" && (len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary() != test.wantSummary):
+				t.Fatalf("PlanModifyString() diagnostics = %v, want %s", resp.Diagnostics, test.wantSummary)
+			}
+			if !resp.PlanValue.Equal(test.wantHash) {
+				t.Errorf("default_value_file_sha256 = %s, want %s", resp.PlanValue, test.wantHash)
+			}
+		})
+	}
+}
+
+func TestReadValueFiles(t *testing.T) {
+	t.Parallel()
+
+	content := `{"items": [1, 2, 3]}`
+	file := valueFile(t, content)
+	param := fileParameter(file, content)
+	placeholder := parameterToAPI(param).DefaultValue.Value
+	if placeholder != fileValuePlaceholder(fileValueHash(content)) {
+		t.Fatalf("parameterToAPI() default value = %q, want the placeholder", placeholder)
+	}
+
+	payload := firebaseclient.RemoteConfigUpdate{
+		Parameters: map[string]firebaseclient.RemoteConfigParameter{
+			"payload": {
+				DefaultValue:      firebaseclient.ConfigValue{Value: "{}"},
+				ConditionalValues: map[string]firebaseclient.ConfigValue{"canary": {Value: placeholder}},
+				ValueType:         "JSON",
+			},
+			"welcome": stringParameter("hello"),
+		},
+	}
+	files := map[string]string{placeholder: file}
+
+	read, err := readValueFiles(payload, files)
+	if err != nil {
+		t.Fatalf("readValueFiles() = %v", err)
+	}
+	if got := read.Parameters["payload"].ConditionalValues["canary"].Value; got != content {
+		t.Errorf("canary value = %q, want the content of the file", got)
+	}
+	if got := read.Parameters["welcome"].DefaultValue.Value; got != "hello" {
+		t.Errorf("welcome = %q, want hello", got)
+	}
+	if got := payload.Parameters["payload"].ConditionalValues["canary"].Value; got != placeholder {
+		t.Errorf("readValueFiles() modified the payload, canary value = %q", got)
+	}
+
+	if err := os.WriteFile(file, []byte(`{"items": []}`), 0o600); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+	if _, err := readValueFiles(payload, files); err == nil || !strings.Contains(err.Error(), "changed since the plan") {
+		t.Errorf("readValueFiles() = %v, want the file changed since the plan", err)
+	}
+}
+
+func TestRedactFileValues(t *testing.T) {
+	t.Parallel()
+
+	content := `{"items": [1, 2, 3]}`
+	file := valueFile(t, content)
+
+	tests := map[string]struct {
+		live     string
+		wantHash string
+	}{
+		"unchanged": {
+			live:     content,
+			wantHash: fileValueHash(content),
+		},
+		"reformatted": {
+			live:     `{"items":[1,2,3]}`,
+			wantHash: fileValueHash(content),
+		},
+		"drifted": {
+			live:     `{"items": [4]}`,
+			wantHash: fileValueHash(`{"items": [4]}`),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			prior := fileParameter(file, content)
+			data := &RemoteConfigResourceModel{
+				Parameters: map[string]RemoteConfigParameterModel{"payload": prior},
+			}
+			live := &firebaseclient.RemoteConfigRead{
+				Parameters: map[string]firebaseclient.RemoteConfigParameter{
+					"payload": {DefaultValue: firebaseclient.ConfigValue{Value: test.live}, ValueType: "JSON"},
+				},
+			}
+			raw, err := json.Marshal(map[string]any{"parameters": live.Parameters})
+			if err != nil {
+				t.Fatalf("Marshal() = %v", err)
+			}
+			live.Raw = raw
+
+			if err := redactFileValues(data, live); err != nil {
+				t.Fatalf("redactFileValues() = %v", err)
+			}
+			want := fileValuePlaceholder(test.wantHash)
+			if got := live.Parameters["payload"].DefaultValue.Value; got != want {
+				t.Errorf("default value = %q, want %q", got, want)
+			}
+			if strings.Contains(string(live.Raw), "items") || !strings.Contains(string(live.Raw), want) {
+				t.Errorf("raw template = %s, want the placeholder %s only", live.Raw, want)
+			}
+
+			refreshed := parameterFromAPI("payload", live.Parameters["payload"], &prior)
+			if refreshed.DefaultValueFileSHA256.ValueString() != test.wantHash || !refreshed.DefaultValue.IsNull() {
+				t.Errorf("refreshed default_value_file_sha256 = %s, default_value = %s, want %s and null", refreshed.DefaultValueFileSHA256, refreshed.DefaultValue, test.wantHash)
+			}
+		})
+	}
+}
+
+func TestParameterFromAPIFile(t *testing.T) {
+	t.Parallel()
+
+	content := `{"items": [1, 2, 3]}`
+	prior := fileParameter(valueFile(t, content), content)
+
+	// firebaseextra_remoteconfig_parameter refreshes from live values that
+	// are not redacted.
+	param := parameterFromAPI("payload", firebaseclient.RemoteConfigParameter{DefaultValue: firebaseclient.ConfigValue{Value: content}, ValueType: "JSON"}, &prior)
+	if param.DefaultValueFileSHA256.ValueString() != fileValueHash(content) || !param.DefaultValue.IsNull() {
+		t.Errorf("default_value_file_sha256 = %s, default_value = %s, want the hash of the file and null", param.DefaultValueFileSHA256, param.DefaultValue)
+	}
+
+	param = parameterFromAPI("payload", firebaseclient.RemoteConfigParameter{DefaultValue: firebaseclient.ConfigValue{UseInAppDefault: true}, ValueType: "JSON"}, &prior)
+	if param.DefaultValueFileSHA256.ValueString() != "" || !param.UseInAppDefault.IsNull() {
+		t.Errorf("default_value_file_sha256 = %s, use_in_app_default = %s, want empty and null", param.DefaultValueFileSHA256, param.UseInAppDefault)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// equivalentValues reports whether two parameter values are served the
+// same: equal but for trailing whitespace, or for JSON parameters the same
+// document whatever its key order and formatting.
+func equivalentValues(valueType string, a, b string) bool {
+	if strings.TrimRightFunc(a, unicode.IsSpace) == strings.TrimRightFunc(b, unicode.IsSpace) {
+		return true
+	}
+	if valueType != "JSON" {
+		return false
+	}
+
+	var decodedA, decodedB any
+	if json.Unmarshal([]byte(a), &decodedA) != nil || json.Unmarshal([]byte(b), &decodedB) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(decodedA, decodedB)
+}
+
+// refreshedValue returns the prior value when the live one is equivalent,
+// so a value reformatted in the console doesn't show up as a change.
+func refreshedValue(valueType string, live, prior types.String) types.String {
+	if live.IsNull() || prior.IsNull() || prior.IsUnknown() {
+		return live
+	}
+	if equivalentValues(valueType, live.ValueString(), prior.ValueString()) {
+		return prior
+	}
+
+	return live
+}
+
+// keepEquivalentValues keeps the prior values of a refreshed parameter the
+// live ones are equivalent to.
+func keepEquivalentValues(param *RemoteConfigParameterModel, prior *RemoteConfigParameterModel) {
+	valueType := param.ValueType.ValueString()
+	param.DefaultValue = refreshedValue(valueType, param.DefaultValue, prior.DefaultValue)
+
+	for condition, value := range param.ConditionalValues {
+		priorValue, ok := prior.ConditionalValues[condition]
+		if !ok {
+			continue
+		}
+		value.Value = refreshedValue(valueType, value.Value, priorValue.Value)
+		if value.RolloutValue != nil && priorValue.RolloutValue != nil {
+			rollout := *value.RolloutValue
+			rollout.Value = refreshedValue(valueType, rollout.Value, priorValue.RolloutValue.Value)
+			value.RolloutValue = &rollout
+		}
+		param.ConditionalValues[condition] = value
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RemoteConfigVersionDataSource{}
+
+func NewRemoteConfigVersionDataSource() datasource.DataSource {
+	return &RemoteConfigVersionDataSource{}
+}
+
+// RemoteConfigVersionDataSource defines the data source implementation.
+type RemoteConfigVersionDataSource struct {
+	client *FirebaseClient
+}
+
+// RemoteConfigVersionDataSourceModel describes the data source data model.
+type RemoteConfigVersionDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Project       types.String `tfsdk:"project"`
+	Namespace     types.String `tfsdk:"namespace"`
+	VersionNumber types.String `tfsdk:"version_number"`
+	TemplateJSON  types.String `tfsdk:"template_json"`
+	Description   types.String `tfsdk:"description"`
+	UpdateTime    types.String `tfsdk:"update_time"`
+	UpdateUser    types.String `tfsdk:"update_user"`
+	UpdateOrigin  types.String `tfsdk:"update_origin"`
+	UpdateType    types.String `tfsdk:"update_type"`
+}
+
+func (d *RemoteConfigVersionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remoteconfig_version"
+}
+
+func (d *RemoteConfigVersionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Template of a past Remote Config version, e.g. to diff versions or restore one through the `template_json` of `firebaseextra_remoteconfig`",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project and version the template was read from",
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Firebase Project ID",
+			},
+			"namespace": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Namespace of the template, defaults to the `default_namespace` of the provider",
+				Validators: []validator.String{
+					stringvalidator.OneOf(firebaseclient.RemoteConfigNamespaces...),
+				},
+			},
+			"version_number": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Version to read",
+			},
+			"template_json": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Template of the version in the JSON format of the REST API, normalized and without version metadata",
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Raw version description",
+			},
+			"update_time": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 time the version was published",
+			},
+			"update_user": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Email of who published the version",
+			},
+			"update_origin": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Where the version was published from, e.g. `CONSOLE` or `REST_API`",
+			},
+			"update_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "How the version was published, e.g. `INCREMENTAL_UPDATE`, `FORCED_UPDATE` or `ROLLBACK`",
+			},
+		},
+	}
+}
+
+func (d *RemoteConfigVersionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+	client.checkScopes(&resp.Diagnostics, "firebaseextra_remoteconfig_version", remoteConfigAPIScopes)
+}
+
+func (d *RemoteConfigVersionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RemoteConfigVersionDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := data.Namespace.ValueString()
+	if data.Namespace.IsNull() {
+		namespace = d.client.defaultNamespace
+	}
+	template := firebaseclient.TemplateRef(data.Project.ValueString(), namespace)
+	target, _, err := d.client.GetRemoteConfig(ctx, template, data.VersionNumber.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read version %s of the remote config of project %s: %s", data.VersionNumber.ValueString(), data.Project.ValueString(), err))
+		return
+	}
+
+	templateJSON, err := normalizeTemplateJSON(target.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to normalize version %s of the remote config of project %s: %s", data.VersionNumber.ValueString(), data.Project.ValueString(), err))
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", template, target.Version.VersionNumber))
+	data.TemplateJSON = types.StringValue(templateJSON)
+	data.Description = types.StringValue(target.Version.Description)
+	data.UpdateTime = types.StringNull()
+	if !target.Version.UpdateTime.IsZero() {
+		data.UpdateTime = types.StringValue(target.Version.UpdateTime.Format(time.RFC3339Nano))
+	}
+	data.UpdateUser = types.StringValue(target.Version.UpdateUser.Email)
+	data.UpdateOrigin = types.StringValue(target.Version.UpdateOrigin)
+	data.UpdateType = types.StringValue(target.Version.UpdateType)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,414 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+
+	"terraform-provider-firebaseextra/firebaseclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RestResource{}
+var _ resource.ResourceWithImportState = &RestResource{}
+
+func NewRestResource() resource.Resource {
+	return &RestResource{}
+}
+
+// RestResource manages an API object with raw JSON requests, for the API
+// surfaces the provider doesn't model.
+type RestResource struct {
+	client *FirebaseClient
+}
+
+// RestResourceModel describes the resource data model.
+type RestResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Path            types.String `tfsdk:"path"`
+	ReadPath        types.String `tfsdk:"read_path"`
+	CreateMethod    types.String `tfsdk:"create_method"`
+	UpdateMethod    types.String `tfsdk:"update_method"`
+	Body            types.String `tfsdk:"body"`
+	DriftAttributes types.Set    `tfsdk:"drift_attributes"`
+	Response        types.String `tfsdk:"response"`
+}
+
+func (r *RestResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rest_resource"
+}
+
+func (r *RestResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a Firebase API object with raw JSON requests sent with the credentials of the provider, for API surfaces the provider doesn't model yet. " +
+			"Paths are relative to `https://firebase.googleapis.com` unless they are absolute `https` urls of a `googleapis.com` host, the only other hosts the access token is sent to. " +
+			"Long running operations are not awaited",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Path of the object, `read_path` with `{name}` resolved. Import with this path",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path the object is created with, e.g. `v1beta1/projects/my-project/webApps`. Changing it re-creates the object, except for imported objects which take it from the configuration",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(requiresReplaceUnlessImported, "Changing the path re-creates the object, unless it was imported", "Changing the path re-creates the object, unless it was imported"),
+				},
+				Validators: []validator.String{
+					restPathValidator{},
+				},
+			},
+			"read_path": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Path the object is read, updated and deleted with, `path` when omitted. " +
+					"`{name}` is replaced with the `name` field of the create response",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(requiresReplaceUnlessImported, "Changing the read path re-creates the object, unless it was imported", "Changing the read path re-creates the object, unless it was imported"),
+				},
+				Validators: []validator.String{
+					restPathValidator{},
+				},
+			},
+			"create_method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("POST"),
+				MarkdownDescription: "Method the object is created with, `POST` by default",
+				Validators: []validator.String{
+					stringvalidator.OneOf("POST", "PUT", "PATCH"),
+				},
+			},
+			"update_method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("PATCH"),
+				MarkdownDescription: "Method the object is updated with, `PATCH` by default. PATCH requests carry the top level keys of `body` as `updateMask`",
+				Validators: []validator.String{
+					stringvalidator.OneOf("PATCH", "PUT", "POST"),
+				},
+			},
+			"body": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "JSON object sent on create and update",
+				Validators: []validator.String{
+					jsonObjectValidator{},
+				},
+			},
+			"drift_attributes": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Top level keys of `body` compared with the live object on refresh, a change plans an update. No drift is detected when omitted",
+			},
+			"response": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "JSON returned by the last request",
+			},
+		},
+	}
+}
+
+func (r *RestResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FirebaseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FirebaseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RestResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "create the object") {
+		return
+	}
+
+	response, err := r.client.CallAPI(ctx, data.CreateMethod.ValueString(), data.Path.ValueString(), []byte(data.Body.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create the object at %s: %s", data.Path.ValueString(), err))
+		return
+	}
+
+	readPath := data.Path.ValueString()
+	if !data.ReadPath.IsUnknown() && !data.ReadPath.IsNull() {
+		readPath = data.ReadPath.ValueString()
+	}
+	if strings.Contains(readPath, "{name}") {
+		var created struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(response, &created); err != nil || created.Name == "" {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("The create response of %s has no name to resolve read_path %s with: %s", data.Path.ValueString(), readPath, response))
+			return
+		}
+		readPath = strings.ReplaceAll(readPath, "{name}", created.Name)
+	}
+
+	if data.ReadPath.IsUnknown() {
+		data.ReadPath = data.Path
+	}
+	data.ID = types.StringValue(readPath)
+	data.Response = restResponse(response)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RestResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ReadPath.IsNull() {
+		// This is when we import the state. The path the object was created
+		// with is unknown, it is taken from the configuration by the next
+		// update instead of planning a replacement.
+		data.ReadPath = data.ID
+		data.CreateMethod = types.StringValue("POST")
+		data.UpdateMethod = types.StringValue("PATCH")
+	}
+
+	response, err := r.client.CallAPI(ctx, "GET", data.ID.ValueString(), nil)
+	if errors.Is(err, firebaseclient.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the object at %s: %s", data.ID.ValueString(), err))
+		return
+	}
+
+	if data.Body.IsNull() {
+		data.Body = restResponse(response)
+	} else {
+		var drift []string
+		resp.Diagnostics.Append(data.DriftAttributes.ElementsAs(ctx, &drift, false)...)
+		body, err := driftedBody(data.Body.ValueString(), response, drift)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to compare the object at %s with its body: %s", data.ID.ValueString(), err))
+			return
+		}
+		if body != "" {
+			data.Body = types.StringValue(body)
+		}
+	}
+	data.Response = restResponse(response)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RestResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "update the object") {
+		return
+	}
+
+	target := data.ID.ValueString()
+	if data.UpdateMethod.ValueString() == "PATCH" {
+		var body map[string]any
+		if err := json.Unmarshal([]byte(data.Body.ValueString()), &body); err == nil && len(body) > 0 {
+			separator := "?"
+			if strings.Contains(target, "?") {
+				separator = "&"
+			}
+			target += separator + url.Values{"updateMask": {strings.Join(slices.Sorted(maps.Keys(body)), ",")}}.Encode()
+		}
+	}
+
+	response, err := r.client.CallAPI(ctx, data.UpdateMethod.ValueString(), target, []byte(data.Body.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update the object at %s: %s", data.ID.ValueString(), err))
+		return
+	}
+	data.Response = restResponse(response)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RestResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkWritable(&resp.Diagnostics, "delete the object") {
+		return
+	}
+
+	_, err := r.client.CallAPI(ctx, "DELETE", data.ID.ValueString(), nil)
+	if err != nil && !errors.Is(err, firebaseclient.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete the object at %s: %s", data.ID.ValueString(), err))
+	}
+}
+
+func (r *RestResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// requiresReplaceUnlessImported re-creates the object when its paths are
+// configured and change, except for imported objects, whose state has no
+// path until the first update stores the configured ones.
+func requiresReplaceUnlessImported(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	var created types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("path"), &created)...)
+	resp.RequiresReplace = !created.IsNull()
+}
+
+// restResponse is the model value of a response body, null when empty.
+func restResponse(response []byte) types.String {
+	if len(strings.TrimSpace(string(response))) == 0 {
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(response))
+}
+
+// driftedBody returns body with the drift attributes of the live object
+// that differ from it, or "" when none differs so the body is kept as
+// written.
+func driftedBody(body string, live []byte, drift []string) (string, error) {
+	if len(drift) == 0 {
+		return "", nil
+	}
+
+	var declared, current map[string]any
+	if err := json.Unmarshal([]byte(body), &declared); err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(live, &current); err != nil {
+		return "", err
+	}
+
+	changed := false
+	for _, key := range drift {
+		value, ok := current[key]
+		if old, declaredKey := declared[key]; declaredKey == ok && reflect.DeepEqual(old, value) {
+			continue
+		}
+		changed = true
+		if ok {
+			declared[key] = value
+		} else {
+			delete(declared, key)
+		}
+	}
+	if !changed {
+		return "", nil
+	}
+
+	drifted, err := json.Marshal(declared)
+	if err != nil {
+		return "", err
+	}
+
+	return string(drifted), nil
+}
+
+// jsonObjectValidator checks that a string is a JSON object.
+type jsonObjectValidator struct{}
+
+func (v jsonObjectValidator) Description(ctx context.Context) string {
+	return "must be a JSON object"
+}
+
+func (v jsonObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &object); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON Object", fmt.Sprintf("The value must be a JSON object: %s", err))
+	}
+}
+
+// restPathValidator checks that a path is relative or a url of a Google API,
+// the access token of the provider is not sent anywhere else.
+type restPathValidator struct{}
+
+func (v restPathValidator) Description(ctx context.Context) string {
+	return "must be a relative path or an https url of googleapis.com"
+}
+
+func (v restPathValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v restPathValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := firebaseclient.CheckRestPath(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Path", fmt.Sprintf("The access token of the provider is only sent to Google APIs: %s", err))
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDriftedBody(t *testing.T) {
+	t.Parallel()
+
+	body := `{"displayName": "App", "enabled": true, "labels": {"team": "growth"}}`
+	tests := map[string]struct {
+		live  string
+		drift []string
+
+		want string
+	}{
+		"no drift attributes": {
+			live: `{"displayName": "Renamed", "enabled": true}`,
+		},
+		"unchanged": {
+			live:  `{"displayName": "App", "enabled": true, "labels": {"team": "growth"}, "name": "projects/p/apps/a"}`,
+			drift: []string{"displayName", "labels"},
+		},
+		"changed": {
+			live:  `{"displayName": "Renamed", "enabled": false, "labels": {"team": "growth"}}`,
+			drift: []string{"displayName"},
+			want:  `{"displayName": "Renamed", "enabled": true, "labels": {"team": "growth"}}`,
+		},
+		"removed live": {
+			live:  `{"displayName": "App", "enabled": true}`,
+			drift: []string{"labels"},
+			want:  `{"displayName": "App", "enabled": true}`,
+		},
+		"added live": {
+			live:  `{"displayName": "App", "enabled": true, "labels": {"team": "growth"}, "description": "console"}`,
+			drift: []string{"description"},
+			want:  `{"displayName": "App", "enabled": true, "labels": {"team": "growth"}, "description": "console"}`,
+		},
+		"undeclared and absent": {
+			live:  `{"displayName": "App", "enabled": true, "labels": {"team": "growth"}}`,
+			drift: []string{"description"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := driftedBody(body, []byte(test.live), test.drift)
+			if err != nil {
+				t.Fatalf("driftedBody() = %v", err)
+			}
+			if test.want == "" || got == "" {
+				if got != test.want {
+					t.Errorf("driftedBody() = %q, want %q", got, test.want)
+				}
+				return
+			}
+			var gotJSON, wantJSON any
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Fatalf("driftedBody() = %q, not JSON: %v", got, err)
+			}
+			if err := json.Unmarshal([]byte(test.want), &wantJSON); err != nil {
+				t.Fatalf("want %q, not JSON: %v", test.want, err)
+			}
+			if !reflect.DeepEqual(gotJSON, wantJSON) {
+				t.Errorf("driftedBody() = %s, want %s", got, test.want)
+			}
+		})
+	}
+
+	if _, err := driftedBody(body, []byte("not json"), []string{"displayName"}); err == nil {
+		t.Errorf("driftedBody() of an invalid live object = nil, want an error")
+	}
+}
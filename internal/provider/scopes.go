@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// OAuth scopes of the Google APIs the provider calls.
+const (
+	scopeCloudPlatform = "https://www.googleapis.com/auth/cloud-platform"
+	scopeFirebase      = "https://www.googleapis.com/auth/firebase"
+	scopeRemoteConfig  = "https://www.googleapis.com/auth/firebase.remoteconfig"
+)
+
+// Scopes accepted by the APIs behind resources and data sources, any one
+// of them is enough.
+var (
+	remoteConfigAPIScopes  = []string{scopeRemoteConfig, scopeFirebase, scopeCloudPlatform}
+	firebaseAPIScopes      = []string{scopeFirebase, scopeCloudPlatform}
+	cloudPlatformAPIScopes = []string{scopeCloudPlatform}
+)
+
+// checkScopes reports an error when the provider requests none of the
+// scopes accepted by the API behind typeName, which would otherwise fail
+// with an opaque permission error on the first request.
+func (c *FirebaseClient) checkScopes(diags *diag.Diagnostics, typeName string, accepted []string) {
+	if c.scopes == nil || slices.ContainsFunc(accepted, func(scope string) bool { return slices.Contains(c.scopes, scope) }) {
+		return
+	}
+
+	var presets []string
+	for _, preset := range []string{scopePresetRemoteConfig, scopePresetFirebase, scopePresetCloudPlatform} {
+		if slices.ContainsFunc(accepted, func(scope string) bool { return slices.Contains(scopePresets[preset], scope) }) {
+			presets = append(presets, preset)
+		}
+	}
+	diags.AddError(
+		"Missing OAuth Scope",
+		fmt.Sprintf("%s needs one of the OAuth scopes %s, the provider only requests %s. Set scope_preset to %s.",
+			typeName, strings.Join(accepted, ", "), strings.Join(c.scopes, ", "), strings.Join(presets, " or ")),
+	)
+}
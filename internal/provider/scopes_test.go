@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestCheckScopes(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		scopes   []string
+		accepted []string
+
+		wantPresets string
+	}{
+		"unscoped tokens": {
+			scopes: nil, accepted: cloudPlatformAPIScopes,
+		},
+		"remoteconfig preset for Remote Config": {
+			scopes: scopePresets[scopePresetRemoteConfig], accepted: remoteConfigAPIScopes,
+		},
+		"remoteconfig preset for Firebase": {
+			scopes: scopePresets[scopePresetRemoteConfig], accepted: firebaseAPIScopes,
+			wantPresets: "Set scope_preset to firebase or cloud-platform.",
+		},
+		"firebase preset for Firebase": {
+			scopes: scopePresets[scopePresetFirebase], accepted: firebaseAPIScopes,
+		},
+		"firebase preset for Cloud KMS": {
+			scopes: scopePresets[scopePresetFirebase], accepted: cloudPlatformAPIScopes,
+			wantPresets: "Set scope_preset to cloud-platform.",
+		},
+		"cloud-platform preset": {
+			scopes: scopePresets[scopePresetCloudPlatform], accepted: cloudPlatformAPIScopes,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &FirebaseClient{scopes: test.scopes}
+			var diags diag.Diagnostics
+			c.checkScopes(&diags, "firebaseextra_example", test.accepted)
+
+			switch {
+			case test.wantPresets == "" && len(diags) > 0:
+				t.Errorf("checkScopes() diagnostics = %v, want none", diags)
+			case test.wantPresets != "" && (len(diags) != 1 || diags[0].Summary() != "Missing OAuth Scope" || !strings.HasSuffix(diags[0].Detail(), test.wantPresets)):
+				t.Errorf("checkScopes() diagnostics = %v, want Missing OAuth Scope ending with %q", diags, test.wantPresets)
+			}
+		})
+	}
+}
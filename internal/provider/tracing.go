@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// tracingServiceName is the service.name of the spans the provider exports.
+const tracingServiceName = "terraform-provider-firebaseextra"
+
+// newTracerProvider returns a tracer provider exporting spans to the
+// OTLP/HTTP collector at endpoint. Terraform stops the provider process
+// without notice, so spans are exported as they end instead of in batches
+// that would be lost.
+func newTracerProvider(ctx context.Context, endpoint string, version string) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(sdkresource.NewSchemaless(
+			attribute.String("service.name", tracingServiceName),
+			attribute.String("service.version", version),
+		)),
+	), nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the desired interfaces.
+var _ function.Function = &ValidateConditionFunction{}
+
+func NewValidateConditionFunction() function.Function {
+	return &ValidateConditionFunction{}
+}
+
+// ValidateConditionFunction checks the syntax of a condition expression
+// before it reaches the API.
+type ValidateConditionFunction struct{}
+
+func (f *ValidateConditionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_condition"
+}
+
+func (f *ValidateConditionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check the syntax of a Remote Config condition expression",
+		MarkdownDescription: "Returns true when `expression` follows the grammar of Remote Config conditions: tests of variables such as `app.id`, `app.version`, `device.os`, " +
+			"`percent` or `dateTime` combined with `&&`, `||`, `!` and parentheses. Fails explaining the first problem otherwise, " +
+			"e.g. in a variable validation block with `can(provider::firebaseextra::validate_condition(var.expression))`. " +
+			"Only the syntax is checked, the values tested are left to the API",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "expression",
+				MarkdownDescription: "Condition expression, e.g. `device.os == 'ios' && app.version.>=(['2.0'])`",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *ValidateConditionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expression string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &expression))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := parseConditionExpression(expression); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid condition expression: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, true))
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the desired interfaces.
+var _ function.Function = &VersionCompareFunction{}
+
+// appVersionPattern matches the versions semantic version conditions
+// compare: up to four numbers separated by dots.
+var appVersionPattern = regexp.MustCompile(`^\d+(\.\d+){0,3}$`)
+
+func NewVersionCompareFunction() function.Function {
+	return &VersionCompareFunction{}
+}
+
+// VersionCompareFunction compares app versions the way semantic version
+// conditions do.
+type VersionCompareFunction struct{}
+
+func (f *VersionCompareFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "version_compare"
+}
+
+func (f *VersionCompareFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compare app versions like Remote Config semantic version conditions",
+		MarkdownDescription: "Returns -1, 0 or 1 when `a` is lower than, equal to or greater than `b`, comparing the numbers separated by dots in turn, missing ones counting as 0, so `1.2` equals `1.2.0`. " +
+			"Fails on versions a semantic version condition can't match, e.g. with a `v` prefix, a pre-release or a build number such as `1.2.3 (45)`, " +
+			"which are easily confused with the marketing version conditions compare",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "a",
+				MarkdownDescription: "App version, e.g. `1.2.3`",
+			},
+			function.StringParameter{
+				Name:                "b",
+				MarkdownDescription: "App version to compare with",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *VersionCompareFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+
+	partsA, err := parseAppVersion(a)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	partsB, err := parseAppVersion(b)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, int64(compareAppVersions(partsA, partsB))))
+}
+
+// parseAppVersion returns the numbers of an app version, explaining what
+// is wrong with versions semantic version conditions can't match.
+func parseAppVersion(version string) ([]uint64, error) {
+	if !appVersionPattern.MatchString(version) {
+		switch {
+		case strings.TrimSpace(version) != version:
+			return nil, fmt.Errorf("app version %q has surrounding spaces", version)
+		case strings.HasPrefix(strings.ToLower(version), "v"):
+			return nil, fmt.Errorf("app version %q has a prefix, use %q", version, version[1:])
+		case strings.ContainsAny(version, "+( "):
+			marketing, _, _ := strings.Cut(strings.NewReplacer("(", " ", "+", " ").Replace(version), " ")
+			if appVersionPattern.MatchString(marketing) {
+				return nil, fmt.Errorf("app version %q has a build number, conditions compare the marketing version only: %q", version, marketing)
+			}
+			return nil, fmt.Errorf("app version %q is not up to four numbers separated by dots", version)
+		case strings.Contains(version, "-"):
+			return nil, fmt.Errorf("app version %q is a pre-release, conditions only compare numbers separated by dots", version)
+		default:
+			return nil, fmt.Errorf("app version %q is not up to four numbers separated by dots", version)
+		}
+	}
+
+	var parts []uint64
+	for _, field := range strings.Split(version, ".") {
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("app version %q: %w", version, err)
+		}
+		parts = append(parts, n)
+	}
+
+	return parts, nil
+}
+
+// compareAppVersions compares versions number by number, missing numbers
+// counting as 0.
+func compareAppVersions(a, b []uint64) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y uint64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := cmp.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseAppVersion(t *testing.T) {
+	t.Parallel()
+
+	valid := map[string][]uint64{
+		"1":           {1},
+		"1.2":         {1, 2},
+		"1.2.3":       {1, 2, 3},
+		"10.20.30.40": {10, 20, 30, 40},
+		"01.2":        {1, 2},
+	}
+	for version, want := range valid {
+		got, err := parseAppVersion(version)
+		if err != nil || !slices.Equal(got, want) {
+			t.Errorf("parseAppVersion(%q) = %v, %v, want %v", version, got, err, want)
+		}
+	}
+
+	invalid := map[string]string{
+		"":                     "is not up to four numbers",
+		"1.2.3.4.5":            "is not up to four numbers",
+		"1..2":                 "is not up to four numbers",
+		"a.b":                  "is not up to four numbers",
+		" 1.2":                 "has surrounding spaces",
+		"v1.2":                 `has a prefix, use "1.2"`,
+		"1.2 (34)":             `has a build number, conditions compare the marketing version only: "1.2"`,
+		"1.2+34":               `conditions compare the marketing version only: "1.2"`,
+		"1.2-beta":             "is a pre-release",
+		"99999999999999999999": "value out of range",
+	}
+	for version, want := range invalid {
+		_, err := parseAppVersion(version)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseAppVersion(%q) = %v, want an error containing %q", version, err, want)
+		}
+	}
+}
+
+func TestCompareAppVersions(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{"1.2", "1.2", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.2.0.0", "1.2", 0},
+		{"1.2", "1.10", -1},
+		{"1.10", "1.9", 1},
+		{"2", "1.99.99", 1},
+		{"1.2", "1.2.1", -1},
+	} {
+		a, err := parseAppVersion(tc.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := parseAppVersion(tc.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := compareAppVersions(a, b); got != tc.want {
+			t.Errorf("compareAppVersions(%s, %s) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}